@@ -18,3 +18,25 @@ provider "podman" {
   uri      = "ssh://<user>@<host>[:port]/run/podman/podman.sock?secure=True"
   identity = "/tmp/ssh_identity_key"
 }
+
+# manage multiple hosts with named connections
+provider "podman" {
+  alias = "hosts"
+  connections = {
+    host1 = {
+      uri      = "ssh://<user>@<host1>[:port]/run/podman/podman.sock?secure=True"
+      identity = "/tmp/ssh_identity_key"
+    }
+    host2 = {
+      uri      = "ssh://<user>@<host2>[:port]/run/podman/podman.sock?secure=True"
+      identity = "/tmp/ssh_identity_key"
+    }
+  }
+}
+
+resource "podman_volume" "data" {
+  provider        = podman.hosts
+  for_each        = toset(["host1", "host2"])
+  connection_name = each.key
+  name            = "data"
+}
@@ -5,9 +5,7 @@ resource "podman_network" "network" {}
 resource "podman_network" "dualstack" {
   name   = "dualstack"
   driver = "bridge"
-  options = {
-    mtu = 1500
-  }
+  mtu    = 1500
   internal = false
   dns      = true
   # enable dual stack
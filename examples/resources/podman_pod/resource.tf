@@ -22,3 +22,159 @@ resource "podman_pod" "pod" {
     },
   ]
 }
+
+# A pod publishing ports
+resource "podman_pod" "web" {
+  name = "web"
+  ports = [
+    {
+      host_port      = 8080
+      container_port = 80
+    },
+    {
+      host_ip        = "127.0.0.1"
+      container_port = 9090
+    },
+  ]
+}
+
+# A pod attached to a network with a static address
+resource "podman_network" "net" {
+  name = "backend"
+  subnets = [
+    {
+      subnet = "10.89.100.0/24"
+    },
+  ]
+}
+
+resource "podman_pod" "backend" {
+  name = "backend"
+  networks = [
+    {
+      name         = podman_network.net.name
+      ipv4_address = "10.89.100.10"
+      aliases      = ["db"]
+    },
+  ]
+}
+
+# A pod using the rootless pasta network
+resource "podman_pod" "rootless" {
+  name                 = "rootless"
+  network_mode         = "pasta"
+  network_mode_options = ["--mtu", "1400"]
+}
+
+# A pod sharing the PID namespace of the host
+resource "podman_pod" "monitoring" {
+  name     = "monitoring"
+  share    = ["ipc", "net", "pid", "uts"]
+  pid_mode = "host"
+}
+
+# A pod using a mirrored pause image
+resource "podman_pod" "mirrored" {
+  name        = "mirrored"
+  infra_image = "registry.example.com/podman/pause:latest"
+}
+
+# A pod without infra container
+resource "podman_pod" "plain" {
+  name  = "plain"
+  infra = false
+}
+
+# A pod with resource limits
+resource "podman_pod" "limited" {
+  name        = "limited"
+  cpus        = 1.5
+  memory      = "512m"
+  memory_swap = "1g"
+  pids_limit  = 100
+}
+
+# A pod with custom name resolution
+resource "podman_pod" "resolver" {
+  name        = "resolver"
+  dns_servers = ["192.0.2.1"]
+  dns_search  = ["example.com"]
+  add_hosts = {
+    db       = "192.0.2.10"
+    database = "192.0.2.10"
+  }
+}
+
+# A rootless pod with the user mapped to its own UID
+resource "podman_pod" "rootless" {
+  name = "rootless"
+  userns = {
+    mode = "keep-id"
+    uid  = 1000
+    gid  = 1000
+  }
+}
+
+# A pod with tmpfs scratch space
+resource "podman_pod" "scratch" {
+  name = "scratch"
+  mounts = [
+    {
+      destination = "/scratch"
+      tmpfs = {
+        size = "64m"
+        mode = "1777"
+        exec = false
+      }
+    },
+  ]
+}
+
+# A pod with the content of an image and an overlay of a host path
+resource "podman_pod" "layered" {
+  name = "layered"
+  mounts = [
+    {
+      destination = "/assets"
+      image = {
+        name = "registry.example.com/assets:latest"
+      }
+    },
+    {
+      destination = "/src"
+      overlay = {
+        path      = "/srv/src"
+        upper_dir = "/srv/overlay/upper"
+        work_dir  = "/srv/overlay/work"
+      }
+    },
+  ]
+}
+
+# A pod with devices
+resource "podman_pod" "devices" {
+  name = "devices"
+  devices = [
+    {
+      path = "/dev/fuse"
+    },
+    {
+      path           = "/dev/serial/by-id/usb-device"
+      container_path = "/dev/ttyUSB0"
+      permissions    = "rw"
+    },
+    {
+      path = "nvidia.com/gpu=all"
+    },
+  ]
+}
+
+# A pod with mounts in the format of the podman --mount and --volume flags
+resource "podman_pod" "mount_strings" {
+  name = "mount-strings"
+  mount_strings = [
+    "type=bind,src=/srv/config,dst=/etc/app,ro,z",
+    "${podman_volume.vol.name}:/data:U",
+    "type=tmpfs,dst=/scratch,tmpfs-size=64m",
+  ]
+}
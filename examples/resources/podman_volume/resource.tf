@@ -14,4 +14,42 @@ resource "podman_volume" "tmpfs" {
     # mount options
     o = "nodev,noexec"
   }
-}
\ No newline at end of file
+}
+
+# A tmpfs volume configured by the typed local options
+resource "podman_volume" "typed" {
+  name = "mytmpfs"
+  local = {
+    type          = "tmpfs"
+    device        = "tmpfs"
+    mount_options = ["noexec"]
+    size          = "64m"
+    uid           = 1000
+    noquota       = true
+  }
+}
+
+# A CIFS share, the credentials are not shown in the plan or in options
+variable "share_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "podman_volume" "cifs" {
+  name = "share"
+  options = {
+    type   = "cifs"
+    device = "//192.0.2.10/share"
+  }
+  sensitive_options = {
+    o = "addr=192.0.2.10,username=user,password=${var.share_password}"
+  }
+}
+
+# A volume which is only destroyed if it is empty,
+# containers using the volume are removed together with it
+resource "podman_volume" "protected" {
+  name                         = "data"
+  force                        = true
+  prevent_destroy_if_not_empty = true
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/containers/podman/v4/pkg/bindings"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,6 +26,13 @@ type podmanProvider struct{}
 
 // providerData can be used to store data from the Terraform configuration.
 type providerData struct {
+	URI         types.String                  `tfsdk:"uri"`
+	Identity    types.String                  `tfsdk:"identity"`
+	Connections map[string]providerConnection `tfsdk:"connections"`
+}
+
+// providerConnection is a named connection which can be selected by resources.
+type providerConnection struct {
 	URI      types.String `tfsdk:"uri"`
 	Identity types.String `tfsdk:"identity"`
 }
@@ -58,6 +66,25 @@ func (p *podmanProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Description: "Local path to the identity file for SSH based connections.",
 				Optional:    true,
 			},
+			"connections": schema.MapNestedAttribute{
+				MarkdownDescription: "Named connections to podman services. " +
+					"Resources can select one of them with the `connection_name` attribute, " +
+					"otherwise the connection configured by `uri` and `identity` is used.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uri": schema.StringAttribute{
+							MarkdownDescription: "Connection URI to the podman service. " +
+								"Supports the same schemes as the provider `uri` attribute.",
+							Required: true,
+						},
+						"identity": schema.StringAttribute{
+							Description: "Local path to the identity file for SSH based connections.",
+							Optional:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -72,9 +99,13 @@ func (p *podmanProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	newPodmanClient(ctx, &resp.Diagnostics, data)
-	if resp.Diagnostics.HasError() {
-		return
+	// Named connections are established on demand by the resources,
+	// the default connection is only verified when it is configured or no named connection exists.
+	if !data.URI.IsNull() || len(data.Connections) == 0 {
+		newPodmanClient(ctx, &resp.Diagnostics, data.URI, data.Identity)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// make podman clent data available
@@ -98,9 +129,29 @@ func (p *podmanProvider) Resources(ctx context.Context) []func() resource.Resour
 	}
 }
 
+// connectionClient initializes the podman connection by the given connection name.
+// An empty name selects the default connection of the provider.
+func (d providerData) connectionClient(ctx context.Context, diags *diag.Diagnostics, name string) context.Context {
+	if name == "" {
+		return newPodmanClient(ctx, diags, d.URI, d.Identity)
+	}
+
+	conn, exist := d.Connections[name]
+	if !exist {
+		diags.AddAttributeError(
+			path.Root("connection_name"),
+			"Unknown podman connection",
+			fmt.Sprintf("The connection %q is not configured in the provider connections.", name),
+		)
+		return nil
+	}
+
+	return newPodmanClient(ctx, diags, conn.URI, conn.Identity)
+}
+
 // newPodmanClient initializes a new podman connection for further usage
 // The final client is the configured connection context
-func newPodmanClient(ctx context.Context, diags *diag.Diagnostics, data providerData) context.Context {
+func newPodmanClient(ctx context.Context, diags *diag.Diagnostics, uriValue types.String, identity types.String) context.Context {
 	// set default to local socket
 	uri := podmanDefaultURI
 
@@ -109,11 +160,11 @@ func newPodmanClient(ctx context.Context, diags *diag.Diagnostics, data provider
 		uri = testuri
 	}
 
-	if data.URI.ValueString() != "" {
-		uri = data.URI.ValueString()
+	if uriValue.ValueString() != "" {
+		uri = uriValue.ValueString()
 	}
 
-	c, err := bindings.NewConnectionWithIdentity(ctx, uri, data.Identity.ValueString(), false)
+	c, err := bindings.NewConnectionWithIdentity(ctx, uri, identity.ValueString(), false)
 	if err != nil {
 		diags.AddError("Failed to initialize connection to podman server", fmt.Sprintf("URI: %s, error: %s", uri, err.Error()))
 	}
@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"context"
+	"os"
 	"testing"
 
+	"github.com/blang/semver/v4"
+	"github.com/containers/podman/v4/pkg/bindings"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -21,8 +25,28 @@ func generateResourceName() string {
 	return "tf-testacc-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 }
 
+// testAccProviderURI returns the podman connection URI used for acceptance testing
+func testAccProviderURI() string {
+	if uri := os.Getenv("TF_ACC_TEST_PROVIDER_PODMAN_URI"); uri != "" {
+		return uri
+	}
+	return podmanDefaultURI
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccPreCheckServerVersion skips the test if the podman server is older than the given version
+func testAccPreCheckServerVersion(t *testing.T, minVersion string) {
+	conn, err := bindings.NewConnection(context.Background(), testAccProviderURI())
+	if err != nil {
+		t.Fatalf("Failed to connect to podman server: %s", err.Error())
+	}
+
+	if v := bindings.ServiceVersion(conn); v.LT(semver.MustParse(minVersion)) {
+		t.Skipf("Podman server version %s is older than required version %s", v, minVersion)
+	}
+}
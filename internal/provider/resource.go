@@ -3,8 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/bindings/system"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
@@ -23,6 +28,16 @@ type (
 	genericResource struct {
 		providerData providerData
 	}
+
+	// genericResourceData is implemented by all resource data types to select the podman connection
+	genericResourceData interface {
+		connectionName() string
+	}
+)
+
+const (
+	// importIDSeparator separates the connection name from the resource name on import
+	importIDSeparator = "/"
 )
 
 // Configures the podman client
@@ -47,7 +62,7 @@ func (g *genericResource) Configure(_ context.Context, req resource.ConfigureReq
 
 func (g genericResource) initClientData(
 	ctx context.Context,
-	data interface{},
+	data genericResourceData,
 	get func(context.Context, interface{}) diag.Diagnostics,
 	diags *diag.Diagnostics,
 ) context.Context {
@@ -61,7 +76,66 @@ func (g genericResource) initClientData(
 		return nil
 	}
 
-	return newPodmanClient(ctx, diags, g.providerData)
+	return g.providerData.connectionClient(ctx, diags, data.connectionName())
+}
+
+// modifyPlanDriverPlugin ensures a configured driver is available as plugin on the podman service,
+// plugins selects the drivers of the resource type from the plugins reported by the service.
+func (g genericResource) modifyPlanDriverPlugin(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plugins func(define.Plugins) []string) {
+	var driver, connection types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("driver"), &driver)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("connection_name"), &connection)...)
+	if resp.Diagnostics.HasError() || driver.IsNull() || driver.IsUnknown() || connection.IsUnknown() {
+		return
+	}
+
+	// an existing resource has been created with an available driver
+	if !req.State.Raw.IsNull() {
+		var stateDriver types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("driver"), &stateDriver)...)
+		if driver.Equal(stateDriver) {
+			return
+		}
+	}
+
+	client := g.providerData.connectionClient(ctx, &resp.Diagnostics, connection.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := system.Info(client, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Podman client error", fmt.Sprintf("Failed to retrieve the available drivers: %s", err.Error()))
+		return
+	}
+
+	available := plugins(info.Plugins)
+	if !utils.StringInSlice(driver.ValueString(), available) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("driver"),
+			"Unsupported driver",
+			fmt.Sprintf("The driver %q is not available on the podman service, available drivers: %s.", driver.ValueString(), strings.Join(available, ", ")),
+		)
+	}
+}
+
+// importStateWithConnection imports the resource by its id,
+// the id can be optionally prefixed with the connection name: <connection>/<id>
+func importStateWithConnection(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if conn, name, found := strings.Cut(req.ID, importIDSeparator); found {
+		if conn == "" || name == "" {
+			resp.Diagnostics.AddError(
+				"Unexpected import identifier",
+				fmt.Sprintf("Expected import identifier with format: name or connection%sname. Got: %q", importIDSeparator, req.ID),
+			)
+			return
+		}
+		id = name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_name"), conn)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 // withGenericAttributes returns re-usable standard type definitions
@@ -95,6 +169,20 @@ func withGenericAttributes(attributes map[string]schema.Attribute) map[string]sc
 		},
 	}
 
+	attributes["connection_name"] = schema.StringAttribute{
+		MarkdownDescription: "Name of the provider connection used to manage the resource. " +
+			"Uses the default provider connection if not set. Changing the connection forces a replacement.",
+		Required: false,
+		Optional: true,
+		Computed: false,
+		Validators: []validator.String{
+			validators.MatchName(),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+
 	attributes["id"] = schema.StringAttribute{
 		Description: "ID of the resource",
 		Computed:    true,
@@ -4,15 +4,22 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	ntypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -27,9 +34,12 @@ type (
 	}
 
 	networkResourceData struct {
-		ID     types.String `tfsdk:"id"`
-		Name   types.String `tfsdk:"name"`
-		Labels types.Map    `tfsdk:"labels"`
+		ID         types.String `tfsdk:"id"`
+		Name       types.String `tfsdk:"name"`
+		Labels     types.Map    `tfsdk:"labels"`
+		Connection types.String `tfsdk:"connection_name"`
+
+		ForceDestroy types.Bool `tfsdk:"force_destroy"`
 
 		DNS      types.Bool `tfsdk:"dns"`
 		IPv6     types.Bool `tfsdk:"ipv6"`
@@ -39,20 +49,48 @@ type (
 		IPAMDriver types.String `tfsdk:"ipam_driver"`
 		Options    types.Map    `tfsdk:"options"`
 
+		SensitiveOptions     types.Map    `tfsdk:"sensitive_options"`
+		SensitiveOptionsHash types.String `tfsdk:"sensitive_options_hash"`
+
+		NetworkInterface types.String `tfsdk:"network_interface"`
+
+		// typed driver options
+		MTU     types.Int64  `tfsdk:"mtu"`
+		VLAN    types.Int64  `tfsdk:"vlan"`
+		Metric  types.Int64  `tfsdk:"metric"`
+		Isolate types.Bool   `tfsdk:"isolate"`
+		Mode    types.String `tfsdk:"mode"`
+		Parent  types.String `tfsdk:"parent"`
+
+		DNSServers types.List `tfsdk:"dns_servers"`
+
 		Subnets []networkResourceSubnetData `tfsdk:"subnets"`
+
+		// computed
+		NetworkID  types.String `tfsdk:"network_id"`
+		Created    types.String `tfsdk:"created"`
+		Containers types.List   `tfsdk:"containers"`
 	}
 
 	networkResourceSubnetData struct {
-		Subnet  types.String `tfsdk:"subnet"`
-		Gateway types.String `tfsdk:"gateway"`
+		Subnet     types.String                   `tfsdk:"subnet"`
+		Gateway    types.String                   `tfsdk:"gateway"`
+		LeaseRange *networkResourceLeaseRangeData `tfsdk:"lease_range"`
+	}
+
+	networkResourceLeaseRangeData struct {
+		StartIP types.String `tfsdk:"start_ip"`
+		EndIP   types.String `tfsdk:"end_ip"`
 	}
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &networkResource{}
-	_ resource.ResourceWithConfigure   = &networkResource{}
-	_ resource.ResourceWithImportState = &networkResource{}
+	_ resource.Resource                   = &networkResource{}
+	_ resource.ResourceWithConfigure      = &networkResource{}
+	_ resource.ResourceWithImportState    = &networkResource{}
+	_ resource.ResourceWithModifyPlan     = &networkResource{}
+	_ resource.ResourceWithValidateConfig = &networkResource{}
 )
 
 // NewNetworkResource creates a new network resource.
@@ -74,7 +112,7 @@ func (r networkResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r networkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manage networks for containers and pods",
-		Attributes: withGenericAttributes(
+		Attributes: withGenericAttributes(withSensitiveOptionsAttributes(
 			map[string]schema.Attribute{
 				"dns": schema.BoolAttribute{
 					MarkdownDescription: "Enable the DNS plugin for this network which if enabled, can perform container to container name resolution. Defaults to `false`.",
@@ -106,9 +144,27 @@ func (r networkResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 
+				"force_destroy": schema.BoolAttribute{
+					MarkdownDescription: "Disconnect all containers from the network before it is destroyed. " +
+						"Otherwise destroying a network which is still in use fails. Defaults to `false`.",
+					Optional: true,
+				},
+
+				"dns_servers": schema.ListAttribute{
+					MarkdownDescription: "List of DNS servers used by the podman DNS resolver of this network (netavark only). " +
+						"The servers are queried in the given order. Changes are applied in place on podman `>= 4.4`.",
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.ValueStringsAre(validators.IsIpAdress()),
+					},
+				},
+
 				"driver": schema.StringAttribute{
 					MarkdownDescription: fmt.Sprintf(
-						"Driver to manage the network. One of `%s`, `%s`, `%s` are currently supported. By podman defaults to `bridge`.",
+						"Driver to manage the network. Podman provides `%s`, `%s`, `%s`, other drivers are netavark plugins. "+
+							"The driver must be available on the podman service. By podman defaults to `bridge`.",
 						ntypes.BridgeNetworkDriver,
 						ntypes.MacVLANNetworkDriver,
 						ntypes.IPVLANNetworkDriver,
@@ -116,11 +172,22 @@ func (r networkResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					Computed: true,
 					Optional: true,
 					Validators: []validator.String{
-						stringvalidator.OneOf(
-							ntypes.BridgeNetworkDriver,
-							ntypes.MacVLANNetworkDriver,
-							ntypes.IPVLANNetworkDriver,
-						),
+						stringvalidator.LengthAtLeast(1),
+					},
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+						modifier.RequiresReplaceComputed(),
+					},
+				},
+
+				"network_interface": schema.StringAttribute{
+					MarkdownDescription: "Name of the network interface on the host. " +
+						"For the `bridge` driver this is the name of the bridge, podman assigns one if not set. " +
+						"For `macvlan` and `ipvlan` it is the parent interface.",
+					Computed: true,
+					Optional: true,
+					Validators: []validator.String{
+						validators.MatchNetworkInterfaceName(),
 					},
 					PlanModifiers: []planmodifier.String{
 						stringplanmodifier.UseStateForUnknown(),
@@ -151,23 +218,76 @@ func (r networkResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 
 				"options": schema.MapAttribute{
-					Description: "Driver specific options.",
+					Description: "Driver specific options. Options with a typed attribute are merged into this map.",
 					Required:    false,
 					Optional:    true,
 					Computed:    true,
 					ElementType: types.StringType,
+					// replacement is planned by the resource, typed options are merged into the map
 					PlanModifiers: []planmodifier.Map{
 						modifier.UseDefaultModifier(utils.MapStringEmpty()),
-						modifier.RequiresReplaceComputed(),
+						mapplanmodifier.UseStateForUnknown(),
+					},
+				},
+
+				"network_id": schema.StringAttribute{
+					MarkdownDescription: "ID of the network assigned by podman.",
+					Computed:            true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+
+				"created": schema.StringAttribute{
+					MarkdownDescription: "Creation time of the network in RFC 3339 format.",
+					Computed:            true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+
+				"containers": schema.ListNestedAttribute{
+					MarkdownDescription: "Containers attached to the network, including the infra containers of pods.",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"id": schema.StringAttribute{
+								MarkdownDescription: "ID of the container.",
+								Computed:            true,
+							},
+							"name": schema.StringAttribute{
+								MarkdownDescription: "Name of the container.",
+								Computed:            true,
+							},
+							"ip_addresses": schema.ListAttribute{
+								MarkdownDescription: "IP addresses of the container in this network in CIDR notation.",
+								Computed:            true,
+								ElementType:         types.StringType,
+							},
+							"mac_address": schema.StringAttribute{
+								MarkdownDescription: "MAC address of the container in this network.",
+								Computed:            true,
+							},
+						},
 					},
 				},
 
 				"subnets": schema.SetNestedAttribute{
-					Description: "Subnets for this network.",
+					Description: "Subnets for this network. Subnets must not overlap and cannot be set with the `dhcp` or `none` ipam driver.",
 					Required:    false,
 					Optional:    true,
 					Computed:    true,
+					Validators: []validator.Set{
+						validators.SubnetsNotOverlapping(),
+					},
+					PlanModifiers: []planmodifier.Set{
+						setplanmodifier.UseStateForUnknown(),
+					},
 					NestedObject: schema.NestedAttributeObject{
+						Validators: []validator.Object{
+							validators.SubnetGateway(),
+							validators.SubnetLeaseRange(),
+						},
 						Attributes: map[string]schema.Attribute{
 							"subnet": schema.StringAttribute{
 								MarkdownDescription: "The subnet in CIDR notation.",
@@ -181,7 +301,7 @@ func (r networkResource) Schema(ctx context.Context, req resource.SchemaRequest,
 								},
 							},
 							"gateway": schema.StringAttribute{
-								MarkdownDescription: "Gateway IP for this Network.",
+								MarkdownDescription: "Gateway IP for this Network, must be within the subnet.",
 								Computed:            true,
 								Optional:            true,
 								Validators: []validator.String{
@@ -192,27 +312,165 @@ func (r networkResource) Schema(ctx context.Context, req resource.SchemaRequest,
 									modifier.RequiresReplaceComputed(),
 								},
 							},
+							"lease_range": schema.SingleNestedAttribute{
+								MarkdownDescription: "Range of IPs within the subnet which are leased to containers. " +
+									"The range must not include the gateway. Defaults to the whole subnet.",
+								Optional: true,
+								PlanModifiers: []planmodifier.Object{
+									objectplanmodifier.RequiresReplace(),
+								},
+								Attributes: map[string]schema.Attribute{
+									"start_ip": schema.StringAttribute{
+										MarkdownDescription: "First IP of the lease range.",
+										Optional:            true,
+										Validators: []validator.String{
+											validators.IsIpAdress(),
+										},
+									},
+									"end_ip": schema.StringAttribute{
+										MarkdownDescription: "Last IP of the lease range.",
+										Optional:            true,
+										Validators: []validator.String{
+											validators.IsIpAdress(),
+										},
+									},
+								},
+							},
 						},
 					},
 				},
 			},
-		),
+		)),
+	}
+
+	for name, attribute := range networkDriverOptionsSchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// connectionName returns the name of the selected provider connection
+func (d networkResourceData) connectionName() string {
+	return d.Connection.ValueString()
+}
+
+// ValidateConfig validates the network configuration.
+func (r networkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data networkResourceData
+	var subnets types.Set
+	for _, attribute := range []struct {
+		name   string
+		target interface{}
+	}{
+		{"driver", &data.Driver},
+		{"ipv6", &data.IPv6},
+		{"ipam_driver", &data.IPAMDriver},
+		{"subnets", &subnets},
+		{"options", &data.Options},
+		{sensitiveOptionsAttribute, &data.SensitiveOptions},
+		{"network_interface", &data.NetworkInterface},
+		{ntypes.MTUOption, &data.MTU},
+		{ntypes.VLANOption, &data.VLAN},
+		{ntypes.MetricOption, &data.Metric},
+		{ntypes.IsolateOption, &data.Isolate},
+		{ntypes.ModeOption, &data.Mode},
+		{networkParentAttribute, &data.Parent},
+	} {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute.name), attribute.target)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSubnets(data, subnets, &resp.Diagnostics)
+	validateDriverOptions(ctx, data, &resp.Diagnostics)
+
+	// options with a typed attribute cannot be sensitive
+	typed := make([]string, 0, len(networkDriverOptions))
+	for key := range networkDriverOptions {
+		typed = append(typed, key)
+	}
+	validateSensitiveOptions(data.Options, data.SensitiveOptions, typed, &resp.Diagnostics)
+}
+
+// validateSubnets ensures the subnets are compatible with the ipam driver and the ipv6 setting
+func validateSubnets(d networkResourceData, subnets types.Set, diags *diag.Diagnostics) {
+	if subnets.IsNull() || subnets.IsUnknown() {
+		return
+	}
+
+	if ipam := d.IPAMDriver.ValueString(); ipam == ntypes.DHCPIPAMDriver || ipam == ntypes.NoneIPAMDriver {
+		diags.AddAttributeError(
+			path.Root("subnets"),
+			"Unsupported subnets",
+			fmt.Sprintf("Subnets cannot be configured with the %q ipam driver.", ipam),
+		)
+		return
+	}
+
+	// podman enables ipv6 implicitly with an ipv6 subnet
+	if d.IPv6.IsNull() || d.IPv6.IsUnknown() || d.IPv6.ValueBool() {
+		return
+	}
+	for _, elem := range subnets.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		subnet, ok := obj.Attributes()["subnet"].(types.String)
+		if !ok || subnet.IsNull() || subnet.IsUnknown() {
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(subnet.ValueString()); err == nil && ipNet.IP.To4() == nil {
+			diags.AddAttributeError(
+				path.Root("subnets").AtSetValue(obj).AtName("subnet"),
+				"IPv6 subnet without IPv6",
+				fmt.Sprintf("The subnet %s is an IPv6 subnet, but ipv6 is disabled.", subnet.ValueString()),
+			)
+		}
 	}
 }
 
+// ModifyPlan plans the values which depend on other attributes.
+func (r networkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	modifyPlanDriverOptions(ctx, req, resp)
+	modifyPlanSensitiveOptions(ctx, req, resp, nil)
+	r.modifyPlanDriverPlugin(ctx, req, resp, func(p define.Plugins) []string { return p.Network })
+}
+
 // toPodmanNetwork converts a resource data to a podman network
 func toPodmanNetwork(ctx context.Context, d networkResourceData, diags *diag.Diagnostics) *ntypes.Network {
 	var nw = &ntypes.Network{
-		Name:        d.Name.ValueString(),
-		Driver:      d.Driver.ValueString(),
-		IPv6Enabled: d.IPv6.ValueBool(),
-		DNSEnabled:  d.DNS.ValueBool(),
-		Internal:    d.Internal.ValueBool(),
+		Name:             d.Name.ValueString(),
+		Driver:           d.Driver.ValueString(),
+		NetworkInterface: d.NetworkInterface.ValueString(),
+		IPv6Enabled:      d.IPv6.ValueBool(),
+		DNSEnabled:       d.DNS.ValueBool(),
+		Internal:         d.Internal.ValueBool(),
 	}
 
 	// Convert map types
 	diags.Append(d.Labels.ElementsAs(ctx, &nw.Labels, true)...)
 	diags.Append(d.Options.ElementsAs(ctx, &nw.Options, true)...)
+	diags.Append(d.DNSServers.ElementsAs(ctx, &nw.NetworkDNSServers, true)...)
+
+	// typed driver options
+	if nw.Options == nil {
+		nw.Options = make(map[string]string)
+	}
+	for key, val := range d.driverOptionValues() {
+		if s, ok := driverOptionString(val); ok {
+			nw.Options[key] = s
+		}
+	}
+	nw.Options = mergeSensitiveOptions(ctx, d.SensitiveOptions, nw.Options, diags)
+	if nw.NetworkInterface == "" {
+		nw.NetworkInterface = d.Parent.ValueString()
+	}
 
 	if !d.IPAMDriver.IsNull() {
 		ipam := map[string]string{
@@ -236,6 +494,15 @@ func toPodmanNetwork(ctx context.Context, d networkResourceData, diags *diag.Dia
 		if !s.Gateway.IsNull() {
 			subnet.Gateway = net.ParseIP(s.Gateway.ValueString())
 		}
+		if s.LeaseRange != nil {
+			subnet.LeaseRange = &ntypes.LeaseRange{}
+			if !s.LeaseRange.StartIP.IsNull() {
+				subnet.LeaseRange.StartIP = net.ParseIP(s.LeaseRange.StartIP.ValueString())
+			}
+			if !s.LeaseRange.EndIP.IsNull() {
+				subnet.LeaseRange.EndIP = net.ParseIP(s.LeaseRange.EndIP.ValueString())
+			}
+		}
 		nw.Subnets = append(nw.Subnets, *subnet)
 	}
 
@@ -243,25 +510,54 @@ func toPodmanNetwork(ctx context.Context, d networkResourceData, diags *diag.Dia
 }
 
 // fromNetwork converts a podman network to a resource data
-func fromPodmanNetwork(n ntypes.Network, diags *diag.Diagnostics) *networkResourceData {
+func fromPodmanNetwork(n ntypes.Network, sensitive types.Map, diags *diag.Diagnostics) *networkResourceData {
+	options, sensitiveHash := splitSensitiveOptions(n.Name, n.Options, sensitive)
 	d := &networkResourceData{
-		ID:       types.StringValue(n.Name),
-		Name:     types.StringValue(n.Name),
-		DNS:      types.BoolValue(n.DNSEnabled),
-		IPv6:     types.BoolValue(n.IPv6Enabled),
-		Internal: types.BoolValue(n.Internal),
-		Driver:   types.StringValue(n.Driver),
-		Labels:   utils.MapStringToMapType(n.Labels, diags),
-		Options:  utils.MapStringToMapType(n.Options, diags),
+		ID:               types.StringValue(n.Name),
+		Name:             types.StringValue(n.Name),
+		DNS:              types.BoolValue(n.DNSEnabled),
+		IPv6:             types.BoolValue(n.IPv6Enabled),
+		Internal:         types.BoolValue(n.Internal),
+		Driver:           types.StringValue(n.Driver),
+		NetworkInterface: types.StringValue(n.NetworkInterface),
+		Labels:           utils.MapStringToMapType(n.Labels, diags),
+		Options:          utils.MapStringToMapType(options, diags),
+
+		SensitiveOptions:     sensitive,
+		SensitiveOptionsHash: sensitiveHash,
 	}
 
 	d.IPAMDriver = utils.MapStringValueToStringType(n.IPAMOptions, "driver")
+	d.setDriverOptionValues(n.Driver, options, diags)
+
+	d.Parent = types.StringNull()
+	if utils.StringInSlice(n.Driver, networkParentDrivers) {
+		d.Parent = types.StringValue(n.NetworkInterface)
+	}
+	d.DNSServers = utils.SliceStringToListType(n.NetworkDNSServers, diags)
+
+	d.NetworkID = types.StringValue(n.ID)
+	d.Created = types.StringValue(n.Created.Format(time.RFC3339))
+	// containers are gathered separately
+	d.Containers = types.ListNull(types.ObjectType{AttrTypes: networkContainerAttrTypes})
 
 	for _, s := range n.Subnets {
 		subnet := networkResourceSubnetData{
 			Subnet:  types.StringValue(s.Subnet.String()),
 			Gateway: types.StringValue(s.Gateway.String()),
 		}
+		if s.LeaseRange != nil {
+			subnet.LeaseRange = &networkResourceLeaseRangeData{
+				StartIP: types.StringNull(),
+				EndIP:   types.StringNull(),
+			}
+			if s.LeaseRange.StartIP != nil {
+				subnet.LeaseRange.StartIP = types.StringValue(s.LeaseRange.StartIP.String())
+			}
+			if s.LeaseRange.EndIP != nil {
+				subnet.LeaseRange.EndIP = types.StringValue(s.LeaseRange.EndIP.String())
+			}
+		}
 		d.Subnets = append(d.Subnets, subnet)
 	}
 	return d
@@ -2,15 +2,36 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
+	"github.com/blang/semver/v4"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/bindings/containers"
 	"github.com/containers/podman/v4/pkg/bindings/network"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/errorhandling"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/project0/terraform-provider-podman/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// networkContainerAttrTypes are the attribute types of an attached container
+var networkContainerAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"ip_addresses": types.ListType{ElemType: types.StringType},
+	"mac_address":  types.StringType,
+}
+
+// networkUpdateMinVersion is the first podman version supporting network updates
+var networkUpdateMinVersion = semver.MustParse("4.4.0")
+
 func (r networkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data networkResourceData
 
@@ -30,7 +51,12 @@ func (r networkResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	diags := resp.State.Set(ctx, fromPodmanNetwork(networkResponse, &resp.Diagnostics))
+	state := fromPodmanNetwork(networkResponse, data.SensitiveOptions, &resp.Diagnostics)
+	state.Connection = data.Connection
+	state.ForceDestroy = data.ForceDestroy
+	state.Containers = networkContainersList(ctx, client, state.Name.ValueString(), &resp.Diagnostics)
+
+	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -56,17 +82,74 @@ func (r networkResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	diags := resp.State.Set(ctx, fromPodmanNetwork(networkResponse, &resp.Diagnostics))
+	state := fromPodmanNetwork(networkResponse, data.SensitiveOptions, &resp.Diagnostics)
+	state.Connection = data.Connection
+	state.ForceDestroy = data.ForceDestroy
+	state.Containers = networkContainersList(ctx, client, state.Name.ValueString(), &resp.Diagnostics)
+
+	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
-// Update is not implemented
+// Update applies the DNS server and force_destroy changes in place, all other attributes require a replacement.
 func (r networkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	utils.AddUnexpectedError(
-		&resp.Diagnostics,
-		"Update triggered for a network resource",
-		"Networks are immutable resources and cannot be updated, it always needs to be replaced.",
-	)
+	var data, state networkResourceData
+
+	client := r.initClientData(ctx, &data, req.Plan.Get, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DNSServers.Equal(state.DNSServers) {
+		var oldServers, newServers []string
+		resp.Diagnostics.Append(state.DNSServers.ElementsAs(ctx, &oldServers, true)...)
+		resp.Diagnostics.Append(data.DNSServers.ElementsAs(ctx, &newServers, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if bindings.ServiceVersion(client).LT(networkUpdateMinVersion) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dns_servers"),
+				"Unsupported podman version",
+				fmt.Sprintf("Updating network DNS servers requires podman >= %s, the server runs %s.", networkUpdateMinVersion, bindings.ServiceVersion(client)),
+			)
+			return
+		}
+
+		// Podman removes the servers first and appends the new ones afterwards,
+		// replacing the whole list keeps the configured order.
+		updateOpts := new(network.UpdateOptions).
+			WithRemoveDNSServers(oldServers).
+			WithAddDNSServers(newServers)
+
+		if err := network.Update(client, state.ID.ValueString(), updateOpts); err != nil {
+			resp.Diagnostics.AddError(
+				"Podman client error",
+				fmt.Sprintf("Failed to update network resource, updates are only supported with the netavark backend: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	networkResponse, err := network.Inspect(client, state.ID.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Podman client error", fmt.Sprintf("Failed to read network resource after update: %s", err.Error()))
+		return
+	}
+
+	result := fromPodmanNetwork(networkResponse, data.SensitiveOptions, &resp.Diagnostics)
+	result.Connection = data.Connection
+	result.ForceDestroy = data.ForceDestroy
+	result.Containers = networkContainersList(ctx, client, result.Name.ValueString(), &resp.Diagnostics)
+
+	diags := resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r networkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -77,7 +160,13 @@ func (r networkResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// TODO: Allow force which detaches containers from network?
+	if data.ForceDestroy.ValueBool() {
+		disconnectNetworkContainers(client, data.ID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	rmErrors, err := network.Remove(client, data.ID.ValueString(), nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Podman client error", fmt.Sprintf("Failed to delete network resource: %s", err.Error()))
@@ -87,10 +176,134 @@ func (r networkResource) Delete(ctx context.Context, req resource.DeleteRequest,
 			resp.Diagnostics.AddError("Error report on deletion for "+e.Name, e.Err.Error())
 		}
 	}
+	if resp.Diagnostics.HasError() {
+		addNetworkContainersDiagnostics(client, data.ID.ValueString(), &resp.Diagnostics)
+		return
+	}
 
 	resp.State.RemoveResource(ctx)
 }
 
+// networkContainers returns all containers attached to the network
+func networkContainers(client context.Context, nameOrID string) ([]entities.ListContainer, error) {
+	listOpts := new(containers.ListOptions).
+		WithAll(true).
+		WithFilters(map[string][]string{"network": {nameOrID}})
+
+	return containers.List(client, listOpts)
+}
+
+// containerName returns the primary name of a listed container
+func containerName(c entities.ListContainer) string {
+	if len(c.Names) > 0 {
+		return c.Names[0]
+	}
+	return c.ID
+}
+
+// networkContainersList returns the attached containers with their addresses in the network
+func networkContainersList(ctx, client context.Context, name string, diags *diag.Diagnostics) types.List {
+	elemType := types.ObjectType{AttrTypes: networkContainerAttrTypes}
+
+	list, err := networkContainers(client, name)
+	if err != nil {
+		diags.AddError("Podman client error", fmt.Sprintf("Failed to list containers of network resource: %s", err.Error()))
+		return types.ListNull(elemType)
+	}
+
+	elems := make([]attr.Value, 0, len(list))
+	for _, c := range list {
+		inspect, err := containers.Inspect(client, c.ID, nil)
+		if err != nil {
+			var errModel *errorhandling.ErrorModel
+			if errors.As(err, &errModel) && errModel.ResponseCode == http.StatusNotFound {
+				// removed in the meantime
+				continue
+			}
+			diags.AddError("Podman client error", fmt.Sprintf("Failed to inspect container %s of network resource: %s", containerName(c), err.Error()))
+			return types.ListNull(elemType)
+		}
+
+		var addresses []string
+		macAddress := types.StringNull()
+		if inspect.NetworkSettings != nil {
+			if n, exist := inspect.NetworkSettings.Networks[name]; exist && n != nil {
+				addresses = networkAddresses(n.InspectBasicNetworkConfig)
+				if n.MacAddress != "" {
+					macAddress = types.StringValue(n.MacAddress)
+				}
+			}
+		}
+
+		ipAddresses, d := types.ListValueFrom(ctx, types.StringType, addresses)
+		diags.Append(d...)
+
+		elem, d := types.ObjectValue(networkContainerAttrTypes, map[string]attr.Value{
+			"id":           types.StringValue(c.ID),
+			"name":         types.StringValue(containerName(c)),
+			"ip_addresses": ipAddresses,
+			"mac_address":  macAddress,
+		})
+		diags.Append(d...)
+		elems = append(elems, elem)
+	}
+
+	result, d := types.ListValue(elemType, elems)
+	diags.Append(d...)
+	return result
+}
+
+// networkAddresses returns all ip addresses of a container network config in CIDR notation
+func networkAddresses(n define.InspectBasicNetworkConfig) []string {
+	addresses := make([]string, 0)
+	if n.IPAddress != "" {
+		addresses = append(addresses, fmt.Sprintf("%s/%d", n.IPAddress, n.IPPrefixLen))
+	}
+	for _, a := range n.SecondaryIPAddresses {
+		addresses = append(addresses, fmt.Sprintf("%s/%d", a.Addr, a.PrefixLength))
+	}
+	if n.GlobalIPv6Address != "" {
+		addresses = append(addresses, fmt.Sprintf("%s/%d", n.GlobalIPv6Address, n.GlobalIPv6PrefixLen))
+	}
+	for _, a := range n.SecondaryIPv6Addresses {
+		addresses = append(addresses, fmt.Sprintf("%s/%d", a.Addr, a.PrefixLength))
+	}
+	return addresses
+}
+
+// disconnectNetworkContainers detaches all containers from the network
+func disconnectNetworkContainers(client context.Context, nameOrID string, diags *diag.Diagnostics) {
+	list, err := networkContainers(client, nameOrID)
+	if err != nil {
+		diags.AddError("Podman client error", fmt.Sprintf("Failed to list containers of network resource: %s", err.Error()))
+		return
+	}
+
+	for _, c := range list {
+		name := containerName(c)
+		if err := network.Disconnect(client, nameOrID, name, new(network.DisconnectOptions).WithForce(true)); err != nil {
+			diags.AddError("Podman client error", fmt.Sprintf("Failed to disconnect container %s from network resource: %s", name, err.Error()))
+		}
+	}
+}
+
+// addNetworkContainersDiagnostics reports every container which is still attached to the network
+func addNetworkContainersDiagnostics(client context.Context, nameOrID string, diags *diag.Diagnostics) {
+	list, err := networkContainers(client, nameOrID)
+	if err != nil {
+		// the deletion error is already reported
+		return
+	}
+
+	for _, c := range list {
+		name := containerName(c)
+		diags.AddError(
+			"Network is in use by container "+name,
+			fmt.Sprintf("The container %s is attached to the network %s. Remove the container or set force_destroy to disconnect it on destroy.", name, nameOrID),
+		)
+	}
+}
+
 func (r networkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithConnection(ctx, req, resp)
 }
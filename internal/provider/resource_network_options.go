@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	ntypes "github.com/containers/common/libnetwork/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/modifier"
+	"github.com/project0/terraform-provider-podman/internal/utils"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+const (
+	networkParentAttribute = "parent"
+)
+
+var (
+	// networkDriverOptions maps the typed driver options to the drivers supporting them,
+	// the attribute names are equal to the option keys.
+	networkDriverOptions = map[string][]string{
+		ntypes.MTUOption:     {ntypes.BridgeNetworkDriver, ntypes.MacVLANNetworkDriver, ntypes.IPVLANNetworkDriver},
+		ntypes.VLANOption:    {ntypes.BridgeNetworkDriver},
+		ntypes.MetricOption:  {ntypes.BridgeNetworkDriver},
+		ntypes.IsolateOption: {ntypes.BridgeNetworkDriver},
+		ntypes.ModeOption:    {ntypes.MacVLANNetworkDriver, ntypes.IPVLANNetworkDriver},
+	}
+
+	// networkBuiltinDrivers are the drivers provided by podman itself, other drivers are plugins
+	networkBuiltinDrivers = []string{ntypes.BridgeNetworkDriver, ntypes.MacVLANNetworkDriver, ntypes.IPVLANNetworkDriver}
+
+	// networkParentDrivers are the drivers attaching to a parent interface
+	networkParentDrivers = []string{ntypes.MacVLANNetworkDriver, ntypes.IPVLANNetworkDriver}
+
+	networkDriverModes = map[string][]string{
+		ntypes.MacVLANNetworkDriver: ntypes.ValidMacVLANModes,
+		ntypes.IPVLANNetworkDriver:  ntypes.ValidIPVLANModes,
+	}
+)
+
+func networkDriverOptionsSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		ntypes.MTUOption: schema.Int64Attribute{
+			MarkdownDescription: networkDriverOptionDescription(ntypes.MTUOption, "MTU of the network interfaces."),
+			Computed:            true,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(68, 65535),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		ntypes.VLANOption: schema.Int64Attribute{
+			MarkdownDescription: networkDriverOptionDescription(ntypes.VLANOption, "VLAN tag of the bridge ports."),
+			Computed:            true,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, 4094),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		ntypes.MetricOption: schema.Int64Attribute{
+			MarkdownDescription: networkDriverOptionDescription(ntypes.MetricOption, "Metric of the default route."),
+			Computed:            true,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, math.MaxUint32),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		ntypes.IsolateOption: schema.BoolAttribute{
+			MarkdownDescription: networkDriverOptionDescription(ntypes.IsolateOption, "Isolate the network from other bridge networks."),
+			Computed:            true,
+			Optional:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		ntypes.ModeOption: schema.StringAttribute{
+			MarkdownDescription: networkDriverOptionDescription(ntypes.ModeOption, fmt.Sprintf(
+				"Mode of the driver, one of `%s` for `macvlan` or one of `%s` for `ipvlan`.",
+				strings.Join(ntypes.ValidMacVLANModes, "`, `"),
+				strings.Join(ntypes.ValidIPVLANModes, "`, `"),
+			)),
+			Computed: true,
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(append(append([]string{}, ntypes.ValidMacVLANModes...), ntypes.ValidIPVLANModes...)...),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		networkParentAttribute: schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf(
+				"Parent interface on the host, supported by the drivers: `%s`. "+
+					"This is an alias to `network_interface` for these drivers.",
+				strings.Join(networkParentDrivers, "`, `"),
+			),
+			Computed: true,
+			Optional: true,
+			Validators: []validator.String{
+				validators.MatchNetworkInterfaceName(),
+			},
+			PlanModifiers: []planmodifier.String{
+				modifier.AlwaysUseStateForUnknown(),
+				modifier.RequiresReplaceComputed(),
+			},
+		},
+	}
+}
+
+func networkDriverOptionDescription(key string, description string) string {
+	return fmt.Sprintf(
+		"%s Sets the driver option `%s`, supported by the drivers: `%s`.",
+		description,
+		key,
+		strings.Join(networkDriverOptions[key], "`, `"),
+	)
+}
+
+// driverOptionValues returns the typed driver options by option key
+func (d networkResourceData) driverOptionValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		ntypes.MTUOption:     d.MTU,
+		ntypes.VLANOption:    d.VLAN,
+		ntypes.MetricOption:  d.Metric,
+		ntypes.IsolateOption: d.Isolate,
+		ntypes.ModeOption:    d.Mode,
+	}
+}
+
+// setDriverOptionValues sets the typed driver options from the raw driver options,
+// options not supported by the driver (e.g. of plugin drivers) are left untyped.
+func (d *networkResourceData) setDriverOptionValues(driver string, options map[string]string, diags *diag.Diagnostics) {
+	supported := make(map[string]string)
+	for key, val := range options {
+		if utils.StringInSlice(driver, networkDriverOptions[key]) {
+			supported[key] = val
+		}
+	}
+
+	d.MTU = utils.MapStringValueToIntType(supported, ntypes.MTUOption, diags)
+	d.VLAN = utils.MapStringValueToIntType(supported, ntypes.VLANOption, diags)
+	d.Metric = utils.MapStringValueToIntType(supported, ntypes.MetricOption, diags)
+	d.Isolate = utils.MapStringValueToBoolType(supported, ntypes.IsolateOption, diags)
+	d.Mode = utils.MapStringValueToStringType(supported, ntypes.ModeOption)
+}
+
+// networkDriverOrDefault returns the configured driver or the podman default driver
+func networkDriverOrDefault(driver types.String) string {
+	if driver.IsNull() {
+		return ntypes.DefaultNetworkDriver
+	}
+	return driver.ValueString()
+}
+
+// setDriverOptionValuesUnknown marks all typed driver options as unknown
+func (d *networkResourceData) setDriverOptionValuesUnknown() {
+	d.MTU = types.Int64Unknown()
+	d.VLAN = types.Int64Unknown()
+	d.Metric = types.Int64Unknown()
+	d.Isolate = types.BoolUnknown()
+	d.Mode = types.StringUnknown()
+}
+
+// driverOptionString converts a typed driver option to the raw option value
+func driverOptionString(v attr.Value) (string, bool) {
+	if v == nil || v.IsNull() || v.IsUnknown() {
+		return "", false
+	}
+	switch val := v.(type) {
+	case types.Int64:
+		return strconv.FormatInt(val.ValueInt64(), 10), true
+	case types.Bool:
+		return strconv.FormatBool(val.ValueBool()), true
+	case types.String:
+		return val.ValueString(), true
+	}
+	return "", false
+}
+
+// validateDriverOptions ensures the typed driver options are supported by the configured driver
+// and do not conflict with the raw driver options.
+func validateDriverOptions(ctx context.Context, d networkResourceData, diags *diag.Diagnostics) {
+	if d.Driver.IsUnknown() {
+		return
+	}
+	driver := networkDriverOrDefault(d.Driver)
+
+	var rawOptions map[string]string
+	if !d.Options.IsUnknown() {
+		diags.Append(d.Options.ElementsAs(ctx, &rawOptions, true)...)
+	}
+
+	// options of plugin drivers are passed through
+	if utils.StringInSlice(driver, networkBuiltinDrivers) {
+		for key, raw := range rawOptions {
+			validateRawDriverOption(driver, key, raw, diags)
+		}
+	}
+
+	for key, val := range d.driverOptionValues() {
+		if val.IsNull() {
+			continue
+		}
+		if !utils.StringInSlice(driver, networkDriverOptions[key]) {
+			diags.AddAttributeError(
+				path.Root(key),
+				"Unsupported driver option",
+				fmt.Sprintf("The option %q is not supported by the %q driver.", key, driver),
+			)
+			continue
+		}
+
+		s, ok := driverOptionString(val)
+		if !ok {
+			continue
+		}
+		if raw, exist := rawOptions[key]; exist && raw != s {
+			diags.AddAttributeError(
+				path.Root("options").AtMapKey(key),
+				"Conflicting driver option",
+				fmt.Sprintf("The option %q is set to %q, but the attribute %q is set to %q.", key, raw, key, s),
+			)
+		}
+	}
+
+	if modes, exist := networkDriverModes[driver]; exist && !d.Mode.IsNull() && !d.Mode.IsUnknown() && !utils.StringInSlice(d.Mode.ValueString(), modes) {
+		diags.AddAttributeError(
+			path.Root(ntypes.ModeOption),
+			"Unsupported driver mode",
+			fmt.Sprintf("The mode %q is not supported by the %q driver, expected one of: %s.", d.Mode.ValueString(), driver, strings.Join(modes, ", ")),
+		)
+	}
+
+	if !d.Parent.IsNull() && !d.Parent.IsUnknown() {
+		if !utils.StringInSlice(driver, networkParentDrivers) {
+			diags.AddAttributeError(
+				path.Root(networkParentAttribute),
+				"Unsupported driver option",
+				fmt.Sprintf("The parent interface is not supported by the %q driver.", driver),
+			)
+		} else if !d.NetworkInterface.IsNull() && !d.NetworkInterface.IsUnknown() && !d.NetworkInterface.Equal(d.Parent) {
+			diags.AddAttributeError(
+				path.Root(networkParentAttribute),
+				"Conflicting parent interface",
+				fmt.Sprintf("The parent %s differs from the network_interface %s.", d.Parent, d.NetworkInterface),
+			)
+		}
+	}
+}
+
+// validateRawDriverOption ensures a raw driver option is known and valid for the driver
+func validateRawDriverOption(driver string, key string, value string, diags *diag.Diagnostics) {
+	optionPath := path.Root("options").AtMapKey(key)
+
+	drivers, exist := networkDriverOptions[key]
+	if !exist || !utils.StringInSlice(driver, drivers) {
+		diags.AddAttributeError(
+			optionPath,
+			"Unsupported driver option",
+			fmt.Sprintf("The option %q is not supported by the %q driver.", key, driver),
+		)
+		return
+	}
+
+	var err error
+	switch key {
+	case ntypes.MTUOption, ntypes.VLANOption, ntypes.MetricOption:
+		_, err = strconv.ParseUint(value, 10, 32)
+	case ntypes.IsolateOption:
+		_, err = strconv.ParseBool(value)
+	case ntypes.ModeOption:
+		if !utils.StringInSlice(value, networkDriverModes[driver]) {
+			err = fmt.Errorf("expected one of: %s", strings.Join(networkDriverModes[driver], ", "))
+		}
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			optionPath,
+			"Invalid driver option",
+			fmt.Sprintf("The value %q of the option %q is invalid: %s", value, key, err.Error()),
+		)
+	}
+}
+
+// modifyPlanDriverOptions keeps the raw driver options and the typed driver options in sync
+func modifyPlanDriverOptions(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configOptions, planOptions types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("options"), &configOptions)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("options"), &planOptions)...)
+
+	configValues := make(map[string]attr.Value, len(networkDriverOptions))
+	configured, unknown := !configOptions.IsNull(), configOptions.IsUnknown()
+	for key := range networkDriverOptions {
+		var val attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(key), &val)...)
+		configValues[key] = val
+		configured = configured || !val.IsNull()
+		unknown = unknown || val.IsUnknown()
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without any configured option the options are taken from state or computed by podman,
+	// otherwise the typed options are merged into the raw options.
+	if unknown {
+		planOptions = types.MapUnknown(types.StringType)
+	} else if configured {
+		var options map[string]string
+		resp.Diagnostics.Append(configOptions.ElementsAs(ctx, &options, true)...)
+		if options == nil {
+			options = make(map[string]string)
+		}
+		for key, val := range configValues {
+			if s, ok := driverOptionString(val); ok {
+				options[key] = s
+			}
+		}
+		planOptions = utils.MapStringToMapType(options, &resp.Diagnostics)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("options"), planOptions)...)
+
+	var driver types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("driver"), &driver)...)
+
+	// The typed options are derived from the planned raw options
+	var planned networkResourceData
+	if planOptions.IsUnknown() || driver.IsUnknown() {
+		planned.setDriverOptionValuesUnknown()
+	} else {
+		options := make(map[string]string)
+		resp.Diagnostics.Append(planOptions.ElementsAs(ctx, &options, true)...)
+		planned.setDriverOptionValues(networkDriverOrDefault(driver), options, &resp.Diagnostics)
+	}
+	for key, val := range planned.driverOptionValues() {
+		if configValues[key].IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(key), val)...)
+		}
+	}
+
+	modifyPlanParent(ctx, req, resp)
+
+	if !req.State.Raw.IsNull() {
+		var stateOptions types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("options"), &stateOptions)...)
+		if !planOptions.Equal(stateOptions) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("options"))
+		}
+	}
+}
+
+// modifyPlanParent plans the parent as alias to the network interface for drivers supporting it
+func modifyPlanParent(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var driver, parent, networkInterface types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("driver"), &driver)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(networkParentAttribute), &parent)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("network_interface"), &networkInterface)...)
+	if resp.Diagnostics.HasError() || driver.IsUnknown() {
+		return
+	}
+
+	if !utils.StringInSlice(driver.ValueString(), networkParentDrivers) {
+		// also covers the default bridge driver
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(networkParentAttribute), types.StringNull())...)
+	} else if !parent.IsNull() && networkInterface.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("network_interface"), parent)...)
+	} else if parent.IsNull() && !networkInterface.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(networkParentAttribute), networkInterface)...)
+	}
+}
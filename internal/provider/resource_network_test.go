@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -23,6 +24,10 @@ func TestAccResourceNetwork_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("podman_network.test", "driver", "bridge"),
 					resource.TestCheckResourceAttr("podman_network.test", "internal", "false"),
 					resource.TestCheckResourceAttr("podman_network.test", "dns", "false"),
+					resource.TestCheckResourceAttrSet("podman_network.test", "network_interface"),
+					resource.TestCheckResourceAttrSet("podman_network.test", "network_id"),
+					resource.TestCheckResourceAttrSet("podman_network.test", "created"),
+					resource.TestCheckResourceAttr("podman_network.test", "containers.#", "0"),
 				),
 			},
 			// ImportState testing
@@ -92,6 +97,273 @@ func TestAccResourceNetwork_dualStack(t *testing.T) {
 	})
 }
 
+func TestAccResourceNetwork_dnsServers(t *testing.T) {
+	name1 := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckServerVersion(t, "4.4.0")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceNetworkDNSServers(name1, `["192.0.2.53"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "dns_servers.#", "1"),
+					resource.TestCheckResourceAttr("podman_network.test", "dns_servers.0", "192.0.2.53"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update in place testing
+			{
+				Config: testAccResourceNetworkDNSServers(name1, `["198.51.100.53", "192.0.2.53"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "dns_servers.#", "2"),
+					resource.TestCheckResourceAttr("podman_network.test", "dns_servers.0", "198.51.100.53"),
+					resource.TestCheckResourceAttr("podman_network.test", "dns_servers.1", "192.0.2.53"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceNetwork_leaseRange(t *testing.T) {
+	name1 := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceNetworkLeaseRange(name1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("podman_network.test", "subnets.*",
+						map[string]string{
+							"subnet":               "198.51.100.0/24",
+							"gateway":              "198.51.100.1",
+							"lease_range.start_ip": "198.51.100.100",
+							"lease_range.end_ip":   "198.51.100.200",
+						},
+					),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceNetwork_networkInterface(t *testing.T) {
+	name1 := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceNetworkInterface(name1, "tfacc-br0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "network_interface", "tfacc-br0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace testing
+			{
+				Config: testAccResourceNetworkInterface(name1, "tfacc-br1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "network_interface", "tfacc-br1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceNetwork_driverOptions(t *testing.T) {
+	name1 := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceNetworkDriverOptions(name1, 1400),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "mtu", "1400"),
+					resource.TestCheckResourceAttr("podman_network.test", "vlan", "10"),
+					resource.TestCheckResourceAttr("podman_network.test", "isolate", "true"),
+					resource.TestCheckResourceAttr("podman_network.test", "options.%", "3"),
+					resource.TestCheckResourceAttr("podman_network.test", "options.mtu", "1400"),
+					resource.TestCheckResourceAttr("podman_network.test", "options.vlan", "10"),
+					resource.TestCheckResourceAttr("podman_network.test", "options.isolate", "true"),
+					resource.TestCheckNoResourceAttr("podman_network.test", "parent"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace testing
+			{
+				Config: testAccResourceNetworkDriverOptions(name1, 9000),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "mtu", "9000"),
+					resource.TestCheckResourceAttr("podman_network.test", "options.mtu", "9000"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceNetwork_forceDestroy(t *testing.T) {
+	name1 := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceNetworkForceDestroy(name1, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "force_destroy", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "podman_network.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy"},
+			},
+			// Update testing
+			{
+				Config: testAccResourceNetworkForceDestroy(name1, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "force_destroy", "true"),
+					resource.TestCheckResourceAttr("podman_network.test", "name", name1),
+				),
+			},
+			// attach the infra container of a pod
+			{
+				Config: testAccResourceNetworkForceDestroy(name1, false) + testAccResourceNetworkPod(name1, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "force_destroy", "false"),
+					resource.TestCheckResourceAttr("podman_network.test", "containers.#", "1"),
+				),
+			},
+			// destroying a network in use fails without force_destroy
+			{
+				Config:      testAccResourceNetworkPod(name1, false),
+				ExpectError: regexp.MustCompile("Network is in use by container"),
+			},
+			{
+				Config: testAccResourceNetworkForceDestroy(name1, true) + testAccResourceNetworkPod(name1, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_network.test", "force_destroy", "true"),
+				),
+			},
+			// the pod is disconnected, its network config differs afterwards
+			{
+				Config:             testAccResourceNetworkPod(name1, false),
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceNetwork_invalidConfig(t *testing.T) {
+	name1 := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  subnets = [{
+    subnet  = "192.0.2.0/24"
+    gateway = "198.51.100.1"
+  }]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Gateway outside of subnet"),
+			},
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  subnets = [
+    { subnet = "192.0.2.0/24" },
+    { subnet = "192.0.2.128/25" },
+  ]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Overlapping subnets"),
+			},
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  ipv6    = false
+  subnets = [{ subnet = "2001:db8::/64" }]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("IPv6 subnet without IPv6"),
+			},
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  driver      = "macvlan"
+  ipam_driver = "dhcp"
+  subnets     = [{ subnet = "192.0.2.0/24" }]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported subnets"),
+			},
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  driver  = "macvlan"
+  options = {
+    vlan = 10
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported driver option"),
+			},
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  sensitive_options = {
+    mtu = 1500
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting driver option"),
+			},
+			{
+				Config: testAccResourceNetworkInvalid(name1, `
+  driver = "tfacc-missing"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported driver"),
+			},
+		},
+	})
+}
+
 func testAccResourceNetwork(name string) string {
 	return fmt.Sprintf(`
 resource "podman_network" "test" {
@@ -126,3 +398,89 @@ func testAccResourceNetworkDualStack(name string) string {
 	}
 `, name)
 }
+
+func testAccResourceNetworkDNSServers(name string, servers string) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name        = %[1]q
+  dns         = true
+  dns_servers = %[2]s
+}
+`, name, servers)
+}
+
+func testAccResourceNetworkLeaseRange(name string) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name = %[1]q
+  subnets = [
+    {
+      subnet  = "198.51.100.0/24"
+      gateway = "198.51.100.1"
+      lease_range = {
+        start_ip = "198.51.100.100"
+        end_ip   = "198.51.100.200"
+      }
+    }
+  ]
+}
+`, name)
+}
+
+func testAccResourceNetworkInterface(name string, iface string) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name              = %[1]q
+  network_interface = %[2]q
+}
+`, name, iface)
+}
+
+func testAccResourceNetworkDriverOptions(name string, mtu int) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name    = %[1]q
+  mtu     = %[2]d
+  vlan    = 10
+  isolate = true
+}
+`, name, mtu)
+}
+
+func testAccResourceNetworkForceDestroy(name string, force bool) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name          = %[1]q
+  force_destroy = %[2]t
+}
+`, name, force)
+}
+
+// testAccResourceNetworkPod attaches a pod to the network by its name,
+// dependsOn orders it after the network resource
+func testAccResourceNetworkPod(name string, dependsOn bool) string {
+	dependency := ""
+	if dependsOn {
+		dependency = "depends_on = [podman_network.test]"
+	}
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  networks = [
+    {
+      name = %[1]q
+    },
+  ]
+  %[2]s
+}
+`, name, dependency)
+}
+
+func testAccResourceNetworkInvalid(name string, attributes string) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name = %[1]q
+%[2]s
+}
+`, name, attributes)
+}
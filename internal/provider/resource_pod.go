@@ -6,7 +6,9 @@ import (
 
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -22,22 +24,60 @@ type (
 		genericResource
 	}
 	podResourceData struct {
-		ID     types.String `tfsdk:"id"`
-		Name   types.String `tfsdk:"name"`
-		Labels types.Map    `tfsdk:"labels"`
+		ID         types.String `tfsdk:"id"`
+		Name       types.String `tfsdk:"name"`
+		Labels     types.Map    `tfsdk:"labels"`
+		Connection types.String `tfsdk:"connection_name"`
 
 		CgroupParent types.String `tfsdk:"cgroup_parent"`
 		Hostname     types.String `tfsdk:"hostname"`
 
-		Mounts shared.Mounts `tfsdk:"mounts"`
+		Infra              types.Bool   `tfsdk:"infra"`
+		InfraImage         types.String `tfsdk:"infra_image"`
+		InfraCommand       types.List   `tfsdk:"infra_command"`
+		InfraName          types.String `tfsdk:"infra_name"`
+		InfraConmonPidFile types.String `tfsdk:"infra_conmon_pidfile"`
+		InfraContainerID   types.String `tfsdk:"infra_container_id"`
+
+		Cpus        types.Float64 `tfsdk:"cpus"`
+		CpusetCpus  types.String  `tfsdk:"cpuset_cpus"`
+		CpusetMems  types.String  `tfsdk:"cpuset_mems"`
+		CPUShares   types.Int64   `tfsdk:"cpu_shares"`
+		Memory      types.String  `tfsdk:"memory"`
+		MemorySwap  types.String  `tfsdk:"memory_swap"`
+		PidsLimit   types.Int64   `tfsdk:"pids_limit"`
+		BlkioWeight types.Int64   `tfsdk:"blkio_weight"`
+
+		Share       types.Set    `tfsdk:"share"`
+		ShareParent types.Bool   `tfsdk:"share_parent"`
+		PidMode     types.String `tfsdk:"pid_mode"`
+		UtsMode     types.String `tfsdk:"uts_mode"`
+
+		Userns *podUserNamespaceData `tfsdk:"userns"`
+
+		Mounts       shared.Mounts   `tfsdk:"mounts"`
+		MountStrings types.List      `tfsdk:"mount_strings"`
+		Devices      []podDeviceData `tfsdk:"devices"`
+
+		DNSServers types.List `tfsdk:"dns_servers"`
+		DNSSearch  types.List `tfsdk:"dns_search"`
+		DNSOptions types.List `tfsdk:"dns_options"`
+		AddHosts   types.Map  `tfsdk:"add_hosts"`
+		NoHosts    types.Bool `tfsdk:"no_hosts"`
+
+		Networks           []podNetworkData `tfsdk:"networks"`
+		NetworkMode        types.String     `tfsdk:"network_mode"`
+		NetworkModeOptions types.List       `tfsdk:"network_mode_options"`
+		Ports              []podPortData    `tfsdk:"ports"`
 	}
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &podResource{}
-	_ resource.ResourceWithConfigure   = &podResource{}
-	_ resource.ResourceWithImportState = &podResource{}
+	_ resource.Resource                   = &podResource{}
+	_ resource.ResourceWithConfigure      = &podResource{}
+	_ resource.ResourceWithImportState    = &podResource{}
+	_ resource.ResourceWithValidateConfig = &podResource{}
 )
 
 // NewPodResource creates a new pod resource.
@@ -86,10 +126,104 @@ func (r podResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 						stringplanmodifier.RequiresReplace(),
 					},
 				},
-				"mounts": mountsAttr.GetSchema(ctx),
+				"mounts":               mountsAttr.GetSchema(ctx),
+				"mount_strings":        mountsAttr.GetStringsSchema(ctx),
+				"devices":              podDevicesSchema(),
+				"networks":             podNetworksSchema(),
+				"network_mode":         podNetworkModeSchema(),
+				"network_mode_options": podNetworkModeOptionsSchema(),
+				"ports":                podPortsSchema(),
+				"userns":               podUserNamespaceSchema(),
 			},
 		),
 	}
+
+	for name, attribute := range podNamespacesSchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range podInfraSchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range podResourcesSchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range podDNSSchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// ValidateConfig validates the pod configuration.
+func (r podResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var infra types.Bool
+	var mode, infraImage, infraName, infraPidFile, pidMode, utsMode types.String
+	var options, infraCommand, dnsServers, dnsSearch, dnsOptions, mountStrings types.List
+	var ports, networks, mounts, devices, share types.Set
+	var addHosts types.Map
+	var noHosts types.Bool
+	var userns types.Object
+	for _, attribute := range []struct {
+		name   string
+		target interface{}
+	}{
+		{"infra", &infra},
+		{"infra_image", &infraImage},
+		{"infra_command", &infraCommand},
+		{"infra_name", &infraName},
+		{"infra_conmon_pidfile", &infraPidFile},
+		{"mounts", &mounts},
+		{"mount_strings", &mountStrings},
+		{"devices", &devices},
+		{"networks", &networks},
+		{"network_mode", &mode},
+		{"network_mode_options", &options},
+		{"ports", &ports},
+		{"share", &share},
+		{"pid_mode", &pidMode},
+		{"uts_mode", &utsMode},
+		{"dns_servers", &dnsServers},
+		{"dns_search", &dnsSearch},
+		{"dns_options", &dnsOptions},
+		{"add_hosts", &addHosts},
+		{"no_hosts", &noHosts},
+		{"userns", &userns},
+	} {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute.name), attribute.target)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePodNetworkMode(mode, options, ports, &resp.Diagnostics)
+	validatePodNameResolution(addHosts, noHosts, &resp.Diagnostics)
+	validatePodUserNamespace(ctx, userns, &resp.Diagnostics)
+	validatePodDevices(ctx, devices, &resp.Diagnostics)
+	shared.ValidateMountStringDestinations(mounts, mountStrings, path.Root("mount_strings"), &resp.Diagnostics)
+	validatePodInfra(infra, map[string]attr.Value{
+		"infra_image":          infraImage,
+		"infra_command":        infraCommand,
+		"infra_name":           infraName,
+		"infra_conmon_pidfile": infraPidFile,
+		"mounts":               mounts,
+		"mount_strings":        mountStrings,
+		"devices":              devices,
+		"networks":             networks,
+		"network_mode":         mode,
+		"network_mode_options": options,
+		"ports":                ports,
+		"pid_mode":             pidMode,
+		"uts_mode":             utsMode,
+		"dns_servers":          dnsServers,
+		"dns_search":           dnsSearch,
+		"dns_options":          dnsOptions,
+		"add_hosts":            addHosts,
+		"no_hosts":             noHosts,
+		"userns":               userns,
+	}, share, &resp.Diagnostics)
+}
+
+// connectionName returns the name of the selected provider connection
+func (d podResourceData) connectionName() string {
+	return d.Connection.ValueString()
 }
 
 func toPodmanPodSpecGenerator(ctx context.Context, d podResourceData, diags *diag.Diagnostics) *specgen.PodSpecGenerator {
@@ -98,16 +232,30 @@ func toPodmanPodSpecGenerator(ctx context.Context, d podResourceData, diags *dia
 		Name:         d.Name.ValueString(),
 		CgroupParent: d.CgroupParent.ValueString(),
 		Hostname:     d.Hostname.ValueString(),
-		Infra:        true,
+		Devices:      toPodmanPodDevices(d.Devices),
 	}
 
 	diags.Append(d.Labels.ElementsAs(ctx, &p.Labels, true)...)
+	toPodmanPodInfraOptions(d, p)
+	toPodmanPodNamespaceOptions(ctx, d, p, diags)
+	toPodmanPodResourceOptions(d, p)
 	sp, err := entities.ToPodSpecGen(*s, p)
 	if err != nil {
 		diags.AddError("Invalid pod configuration", fmt.Sprintf("Cannot build pod configuration: %q", err.Error()))
+		return s
 	}
+	toPodmanPodInfraSpec(ctx, d, sp, diags)
+	toPodmanPodNamespaceSpec(d, sp, diags)
+	toPodmanPodUserNamespace(ctx, d.Userns, sp, diags)
+	toPodmanPodResourceLimits(d, sp, diags)
 	// add storage
-	sp.Volumes, sp.Mounts = d.Mounts.ToPodmanSpec(diags)
+	mounts := append(shared.Mounts{}, d.Mounts...)
+	mounts = append(mounts, shared.MountsFromStrings(ctx, d.MountStrings, path.Root("mount_strings"), diags)...)
+	sp.Volumes, sp.Mounts, sp.OverlayVolumes, sp.ImageVolumes = mounts.ToPodmanSpec(diags)
+	// add network
+	toPodmanPodNetworkConfig(ctx, d, sp, diags)
+	toPodmanPodNameResolution(ctx, d, sp, diags)
+	sp.PortMappings = toPodmanPortMappings(d.Ports)
 	if err := sp.Validate(); err != nil {
 		diags.AddError("Invalid pod configuration", fmt.Sprintf("Cannot build pod configuration: %q", err.Error()))
 	}
@@ -127,6 +275,13 @@ func fromPodResponse(p *entities.PodInspectReport, diags *diag.Diagnostics) *pod
 		Mounts:       shared.FromPodmanToMounts(diags, p.Mounts),
 		CgroupParent: types.StringValue(p.CgroupParent),
 		Hostname:     hostname,
+		Share:        fromPodmanPodShare(p.SharedNamespaces, diags),
+		PidMode:      types.StringNull(),
+		UtsMode:      types.StringNull(),
+	}
+	if p.InfraConfig != nil {
+		d.PidMode = fromPodmanPodNamespaceMode(p.InfraConfig.PidNS)
+		d.UtsMode = fromPodmanPodNamespaceMode(p.InfraConfig.UtsNS)
 	}
 
 	return d
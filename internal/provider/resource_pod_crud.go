@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/containers/podman/v4/pkg/bindings/containers"
 	"github.com/containers/podman/v4/pkg/bindings/pods"
 	"github.com/containers/podman/v4/pkg/domain/entities"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/project0/terraform-provider-podman/internal/provider/shared"
 	"github.com/project0/terraform-provider-podman/internal/utils"
 )
 
@@ -44,8 +47,16 @@ func (r podResource) Create(ctx context.Context, req resource.CreateRequest, res
 	tflog.Info(ctx, "read pod: %v", map[string]interface{}{"response": m})
 
 	// Set state
+	state := fromPodResponse(podResponse, &resp.Diagnostics)
+	state.Connection = data.Connection
+	state.ShareParent = data.ShareParent
+	state.Userns = data.Userns
+	fromPodmanPodResources(podResponse, data, state)
+	fromPodmanPodNameResolution(podResponse, data, state, &resp.Diagnostics)
+	fromPodInfraResponse(ctx, client, podResponse, data, state, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(
-		resp.State.Set(ctx, fromPodResponse(podResponse, &resp.Diagnostics))...,
+		resp.State.Set(ctx, state)...,
 	)
 }
 
@@ -72,8 +83,16 @@ func (r podResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 
 	// Set state
+	state := fromPodResponse(podResponse, &resp.Diagnostics)
+	state.Connection = data.Connection
+	state.ShareParent = data.ShareParent
+	state.Userns = data.Userns
+	fromPodmanPodResources(podResponse, data, state)
+	fromPodmanPodNameResolution(podResponse, data, state, &resp.Diagnostics)
+	fromPodInfraResponse(ctx, client, podResponse, data, state, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(
-		resp.State.Set(ctx, fromPodResponse(podResponse, &resp.Diagnostics))...,
+		resp.State.Set(ctx, state)...,
 	)
 }
 
@@ -104,6 +123,37 @@ func (r podResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 	resp.State.RemoveResource(ctx)
 }
 
+// fromPodInfraResponse sets the infra container and its network configuration.
+// Configuration not reported by podman is kept from the prior data.
+func fromPodInfraResponse(ctx, client context.Context, p *entities.PodInspectReport, prior podResourceData, state *podResourceData, diags *diag.Diagnostics) {
+	state.NetworkMode = types.StringNull()
+	state.NetworkModeOptions = types.ListNull(types.StringType)
+	state.MountStrings = types.ListNull(types.StringType)
+	if p.InfraConfig == nil || p.InfraContainerID == "" {
+		fromPodmanPodInfra(nil, prior, state)
+		return
+	}
+
+	infra, err := containers.Inspect(client, p.InfraContainerID, nil)
+	if err != nil {
+		diags.AddError("Podman client error", fmt.Sprintf("Failed to read (inspect) infra container of pod resource: %s", err.Error()))
+		return
+	}
+
+	fromPodmanPodInfra(infra, prior, state)
+	if infra.HostConfig != nil {
+		// tmpfs mounts and devices of the pod are only reported by the infra container
+		state.Mounts = append(state.Mounts, shared.FromPodmanTmpfsToMounts(infra.HostConfig.Tmpfs)...)
+		state.Devices = fromPodmanPodDevices(infra.HostConfig.Devices, prior.Devices)
+		state.Userns = fromPodmanPodUserNamespace(infra.HostConfig, prior.Userns)
+	}
+	state.Mounts = state.Mounts.WithOverlayMounts(prior.Mounts).Normalize(prior.Mounts)
+	state.Mounts, state.MountStrings = state.Mounts.WithoutMountStrings(ctx, prior.MountStrings, diags)
+	state.Ports = fromPodmanPortBindings(p.InfraConfig.PortBindings, prior.Ports)
+	state.Networks = fromPodmanPodNetworks(p.InfraConfig.Networks, infra, prior.Networks, diags)
+	state.NetworkMode, state.NetworkModeOptions = fromPodmanPodNetworkMode(infra, p.InfraConfig.NetworkOptions, diags)
+}
+
 func (r podResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithConnection(ctx, req, resp)
 }
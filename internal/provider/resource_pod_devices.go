@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+type (
+	// podDeviceData adds a host or CDI device to the pod
+	podDeviceData struct {
+		Path          types.String `tfsdk:"path"`
+		ContainerPath types.String `tfsdk:"container_path"`
+		Permissions   types.String `tfsdk:"permissions"`
+	}
+)
+
+func podDevicesSchema() schema.Attribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Devices added to the infra container of the pod, the containers of the pod inherit them.",
+		Optional:            true,
+		PlanModifiers: []planmodifier.Set{
+			setplanmodifier.RequiresReplace(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"path": schema.StringAttribute{
+					MarkdownDescription: "Path of the device on the host, e.g. `/dev/fuse`, " +
+						"or the fully qualified name of a CDI device, e.g. `vendor.com/class=name`.",
+					Required: true,
+					Validators: []validator.String{
+						validators.MatchDevice(),
+					},
+				},
+				"container_path": schema.StringAttribute{
+					MarkdownDescription: "Path of the device in the containers. Uses the host path if not set. Not supported by CDI devices.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.MatchAbsolutePath(),
+					},
+				},
+				"permissions": schema.StringAttribute{
+					MarkdownDescription: "Cgroup permissions of the device, any combination of `r` (read), `w` (write) and `m` (mknod). " +
+						"Podman allows `rwm` if not set. Not supported by CDI devices.",
+					Optional: true,
+					Validators: []validator.String{
+						validators.MatchDevicePermissions(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// isCDI checks if the device is referenced by the name of a CDI device
+func (d podDeviceData) isCDI() bool {
+	return !d.Path.IsUnknown() && !strings.HasPrefix(d.Path.ValueString(), "/")
+}
+
+// containerPath returns the configured path in the container or the host path
+func (d podDeviceData) containerPath() string {
+	if d.ContainerPath.IsNull() || d.ContainerPath.IsUnknown() {
+		return d.Path.ValueString()
+	}
+	return d.ContainerPath.ValueString()
+}
+
+// validatePodDevices ensures CDI devices are not configured with options of host devices
+func validatePodDevices(ctx context.Context, devices types.Set, diags *diag.Diagnostics) {
+	if devices.IsNull() || devices.IsUnknown() {
+		return
+	}
+	var data []podDeviceData
+	diags.Append(devices.ElementsAs(ctx, &data, false)...)
+	for _, d := range data {
+		if !d.isCDI() {
+			continue
+		}
+		if !d.ContainerPath.IsNull() || !d.Permissions.IsNull() {
+			diags.AddAttributeError(
+				path.Root("devices"),
+				"Unsupported CDI device options",
+				fmt.Sprintf("The CDI device %s does not support a container path or permissions.", d.Path.ValueString()),
+			)
+		}
+	}
+}
+
+// toPodmanPodDevices converts the devices to the podman device format
+func toPodmanPodDevices(devices []podDeviceData) []string {
+	specDevices := make([]string, 0, len(devices))
+	for _, d := range devices {
+		device := d.Path.ValueString()
+		if !d.isCDI() {
+			device = fmt.Sprintf("%s:%s", device, d.containerPath())
+			if !d.Permissions.IsNull() {
+				device = fmt.Sprintf("%s:%s", device, d.Permissions.ValueString())
+			}
+		}
+		specDevices = append(specDevices, device)
+	}
+	return specDevices
+}
+
+// fromPodmanPodDevices converts the devices of the infra container.
+// Podman reports the resolved host path without permissions, known devices keep the representation of the prior devices.
+// CDI devices are injected by their specification and cannot be read back, they are kept from the prior devices
+// and further reported devices are not added as they may originate from the CDI devices.
+func fromPodmanPodDevices(devices []define.InspectDevice, prior []podDeviceData) []podDeviceData {
+	reported := make(map[string]define.InspectDevice, len(devices))
+	for _, d := range devices {
+		reported[d.PathInContainer] = d
+	}
+
+	result := make([]podDeviceData, 0, len(devices))
+	hasCDI := false
+	for _, p := range prior {
+		if p.isCDI() {
+			hasCDI = true
+			result = append(result, p)
+			continue
+		}
+		if _, ok := reported[p.containerPath()]; ok {
+			delete(reported, p.containerPath())
+			result = append(result, p)
+		}
+	}
+
+	if !hasCDI {
+		for _, d := range devices {
+			if _, ok := reported[d.PathInContainer]; !ok {
+				continue
+			}
+			device := podDeviceData{
+				Path:          types.StringValue(d.PathOnHost),
+				ContainerPath: types.StringNull(),
+				Permissions:   types.StringNull(),
+			}
+			if d.PathInContainer != d.PathOnHost {
+				device.ContainerPath = types.StringValue(d.PathInContainer)
+			}
+			result = append(result, device)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/utils"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+func podDNSSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"dns_servers": schema.ListAttribute{
+			MarkdownDescription: "DNS servers written to the resolv.conf shared by the containers of the pod.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(validators.IsIpAdress()),
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"dns_search": schema.ListAttribute{
+			MarkdownDescription: "DNS search domains written to the resolv.conf shared by the containers of the pod.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"dns_options": schema.ListAttribute{
+			MarkdownDescription: "DNS options written to the resolv.conf shared by the containers of the pod, e.g. `ndots:2`.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"add_hosts": schema.MapAttribute{
+			MarkdownDescription: "Additional entries of the /etc/hosts shared by the containers of the pod, mapping a hostname to an IP. " +
+				"Aliases are added by mapping multiple hostnames to the same IP.",
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.SizeAtLeast(1),
+				mapvalidator.KeysAre(validators.MatchName()),
+				mapvalidator.ValueStringsAre(validators.IsIpAdress()),
+			},
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
+		},
+		"no_hosts": schema.BoolAttribute{
+			MarkdownDescription: "Do not manage the /etc/hosts of the pod, containers use the /etc/hosts of their image.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+// validatePodNameResolution ensures no hosts are added to an unmanaged /etc/hosts
+func validatePodNameResolution(addHosts types.Map, noHosts types.Bool, diags *diag.Diagnostics) {
+	if addHosts.IsNull() || noHosts.IsNull() || noHosts.IsUnknown() || !noHosts.ValueBool() {
+		return
+	}
+	diags.AddAttributeError(
+		path.Root("add_hosts"),
+		"Unsupported hosts",
+		"Hosts cannot be added if the /etc/hosts is not managed (no_hosts).",
+	)
+}
+
+// toPodmanPodNameResolution adds the resolv.conf and /etc/hosts configuration to the pod spec
+func toPodmanPodNameResolution(ctx context.Context, d podResourceData, sp *specgen.PodSpecGenerator, diags *diag.Diagnostics) {
+	sp.NoManageHosts = d.NoHosts.ValueBool()
+
+	if !d.DNSServers.IsNull() {
+		var servers []string
+		diags.Append(d.DNSServers.ElementsAs(ctx, &servers, false)...)
+		sp.DNSServer = toPodmanDNSServers(servers, diags)
+	}
+	if !d.DNSSearch.IsNull() {
+		diags.Append(d.DNSSearch.ElementsAs(ctx, &sp.DNSSearch, false)...)
+	}
+	if !d.DNSOptions.IsNull() {
+		diags.Append(d.DNSOptions.ElementsAs(ctx, &sp.DNSOption, false)...)
+	}
+	if !d.AddHosts.IsNull() {
+		hosts := make(map[string]string, len(d.AddHosts.Elements()))
+		diags.Append(d.AddHosts.ElementsAs(ctx, &hosts, false)...)
+		names := make([]string, 0, len(hosts))
+		for name := range hosts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sp.HostAdd = append(sp.HostAdd, fmt.Sprintf("%s:%s", name, hosts[name]))
+		}
+	}
+}
+
+// toPodmanDNSServers parses the ip addresses of the dns servers
+func toPodmanDNSServers(servers []string, diags *diag.Diagnostics) []net.IP {
+	ips := make([]net.IP, 0, len(servers))
+	for _, server := range servers {
+		ip := net.ParseIP(server)
+		if ip == nil {
+			diags.AddAttributeError(
+				path.Root("dns_servers"),
+				"Invalid DNS server",
+				fmt.Sprintf("Cannot parse IP address of DNS server %s", server),
+			)
+			continue
+		}
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
+// fromPodmanPodNameResolution converts the resolv.conf and /etc/hosts configuration of the infra container
+func fromPodmanPodNameResolution(p *entities.PodInspectReport, prior podResourceData, state *podResourceData, diags *diag.Diagnostics) {
+	state.DNSServers = types.ListNull(types.StringType)
+	state.DNSSearch = types.ListNull(types.StringType)
+	state.DNSOptions = types.ListNull(types.StringType)
+	state.AddHosts = types.MapNull(types.StringType)
+	state.NoHosts = types.BoolNull()
+	if p.InfraConfig == nil {
+		return
+	}
+	config := p.InfraConfig
+
+	state.DNSServers = utils.SliceStringToListType(config.DNSServer, diags)
+	state.DNSSearch = utils.SliceStringToListType(config.DNSSearch, diags)
+	state.DNSOptions = utils.SliceStringToListType(config.DNSOption, diags)
+
+	if len(config.HostAdd) > 0 {
+		hosts := make(map[string]string, len(config.HostAdd))
+		for _, host := range config.HostAdd {
+			// ipv6 addresses contain colons, hostnames do not
+			name, ip, _ := strings.Cut(host, ":")
+			hosts[name] = ip
+		}
+		state.AddHosts = utils.MapStringToMapType(hosts, diags)
+	}
+
+	// the /etc/hosts is managed by default, an explicit false is kept
+	if config.NoManageHosts || !prior.NoHosts.IsNull() {
+		state.NoHosts = types.BoolValue(config.NoManageHosts)
+	}
+}
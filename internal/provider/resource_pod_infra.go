@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/modifier"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+var (
+	// podInfraAttributes are the attributes requiring an infra container
+	podInfraAttributes = []string{
+		"infra_image",
+		"infra_command",
+		"infra_name",
+		"infra_conmon_pidfile",
+		"mounts",
+		"mount_strings",
+		"devices",
+		"networks",
+		"network_mode",
+		"network_mode_options",
+		"ports",
+		"pid_mode",
+		"uts_mode",
+		"dns_servers",
+		"dns_search",
+		"dns_options",
+		"add_hosts",
+		"no_hosts",
+		"userns",
+	}
+)
+
+func podInfraSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"infra": schema.BoolAttribute{
+			MarkdownDescription: "Create an infra container holding the namespaces of the pod. " +
+				"Pods without infra container cannot share namespaces, publish ports or attach networks. Defaults to `true`.",
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.Bool{
+				modifier.UseDefaultModifier(types.BoolValue(true)),
+				boolplanmodifier.UseStateForUnknown(),
+				modifier.RequiresReplaceComputed(),
+			},
+		},
+		"infra_image": schema.StringAttribute{
+			MarkdownDescription: "Image of the infra container. Podman uses the pause image of its configuration if not set.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				modifier.RequiresReplaceComputed(),
+			},
+		},
+		"infra_command": schema.ListAttribute{
+			MarkdownDescription: "Command of the infra container. Uses the entrypoint of the infra image if not set.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"infra_name": schema.StringAttribute{
+			MarkdownDescription: "Name of the infra container. Podman derives the name from the pod ID if not set.",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				validators.MatchName(),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				modifier.RequiresReplaceComputed(),
+			},
+		},
+		"infra_conmon_pidfile": schema.StringAttribute{
+			MarkdownDescription: "Path of the file the PID of the conmon process of the infra container is written to.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"infra_container_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the infra container.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+// validatePodInfra ensures no attributes requiring an infra container are configured without it
+func validatePodInfra(infra types.Bool, attributes map[string]attr.Value, share types.Set, diags *diag.Diagnostics) {
+	if infra.IsNull() || infra.IsUnknown() || infra.ValueBool() {
+		return
+	}
+
+	for _, name := range podInfraAttributes {
+		if value, ok := attributes[name]; ok && !value.IsNull() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Missing infra container",
+				fmt.Sprintf("The attribute %s requires an infra container, it cannot be set when infra is disabled.", name),
+			)
+		}
+	}
+
+	if !share.IsNull() && !share.IsUnknown() && len(share.Elements()) > 0 {
+		diags.AddAttributeError(
+			path.Root("share"),
+			"Missing infra container",
+			"Namespaces can only be shared with an infra container, it cannot be set when infra is disabled.",
+		)
+	}
+}
+
+// podInfraEnabled checks if the pod is created with an infra container
+func (d podResourceData) podInfraEnabled() bool {
+	return d.Infra.IsNull() || d.Infra.IsUnknown() || d.Infra.ValueBool()
+}
+
+// toPodmanPodInfraOptions adds the infra container to the pod create options
+func toPodmanPodInfraOptions(d podResourceData, p *entities.PodCreateOptions) {
+	p.Infra = d.podInfraEnabled()
+	if !d.InfraImage.IsUnknown() {
+		p.InfraImage = d.InfraImage.ValueString()
+	}
+	if !d.InfraName.IsUnknown() {
+		p.InfraName = d.InfraName.ValueString()
+	}
+	p.InfraConmonPidFile = d.InfraConmonPidFile.ValueString()
+}
+
+// toPodmanPodInfraSpec adds the infra command, the create options split the command by spaces
+func toPodmanPodInfraSpec(ctx context.Context, d podResourceData, sp *specgen.PodSpecGenerator, diags *diag.Diagnostics) {
+	if d.InfraCommand.IsNull() || d.InfraCommand.IsUnknown() {
+		return
+	}
+	diags.Append(d.InfraCommand.ElementsAs(ctx, &sp.InfraCommand, false)...)
+}
+
+// fromPodmanPodInfra converts the infra container of the pod.
+// The command and conmon pidfile are not distinguishable from the podman defaults, they are kept from the prior data.
+func fromPodmanPodInfra(infra *define.InspectContainerData, prior podResourceData, state *podResourceData) {
+	state.InfraCommand = prior.InfraCommand
+	state.InfraConmonPidFile = prior.InfraConmonPidFile
+	if infra == nil {
+		state.Infra = types.BoolValue(false)
+		state.InfraImage = types.StringNull()
+		state.InfraName = types.StringNull()
+		state.InfraContainerID = types.StringNull()
+		return
+	}
+
+	state.Infra = types.BoolValue(true)
+	state.InfraImage = types.StringValue(infra.ImageName)
+	state.InfraName = types.StringValue(infra.Name)
+	state.InfraContainerID = types.StringValue(infra.ID)
+}
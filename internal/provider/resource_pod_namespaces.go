@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/modifier"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+const (
+	// podShareNone disables the sharing of namespaces
+	podShareNone = "none"
+)
+
+var (
+	// podShareableNamespaces are the namespaces the containers of a pod can share
+	podShareableNamespaces = []string{"cgroup", "ipc", "net", "pid", "uts"}
+)
+
+func podNamespacesSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"share": schema.SetAttribute{
+			MarkdownDescription: fmt.Sprintf(
+				"Namespaces shared by the containers of the pod, any of `%s`. "+
+					"An empty set disables sharing. Podman shares `%s` if not set.",
+				strings.Join(podShareableNamespaces, "`, `"),
+				strings.ReplaceAll(specgen.DefaultKernelNamespaces, ",", "`, `"),
+			),
+			Optional:    true,
+			Computed:    true,
+			ElementType: types.StringType,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(stringvalidator.OneOf(podShareableNamespaces...)),
+			},
+			PlanModifiers: []planmodifier.Set{
+				setplanmodifier.UseStateForUnknown(),
+				modifier.RequiresReplaceComputed(),
+			},
+		},
+		"share_parent": schema.BoolAttribute{
+			MarkdownDescription: "Use the cgroup of the pod as the cgroup parent of its containers. Podman defaults to `true`.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.RequiresReplace(),
+			},
+		},
+		"pid_mode": podNamespaceModeSchema("PID"),
+		"uts_mode": podNamespaceModeSchema("UTS"),
+	}
+}
+
+func podNamespaceModeSchema(namespace string) schema.Attribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"%s namespace of the pod, `private`, `host` or the path of a namespace as `ns:<path>`. Defaults to `private`.",
+			namespace,
+		),
+		Optional: true,
+		Computed: true,
+		Validators: []validator.String{
+			validators.MatchNamespaceMode(),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+	}
+}
+
+// toPodmanPodNamespaceOptions adds the shared namespaces to the pod create options
+func toPodmanPodNamespaceOptions(ctx context.Context, d podResourceData, p *entities.PodCreateOptions, diags *diag.Diagnostics) {
+	if !d.Share.IsNull() && !d.Share.IsUnknown() && d.podInfraEnabled() {
+		diags.Append(d.Share.ElementsAs(ctx, &p.Share, false)...)
+		if len(p.Share) == 0 {
+			// an empty list is handled as default by podman
+			p.Share = []string{podShareNone}
+		}
+	}
+	if !d.ShareParent.IsNull() {
+		shareParent := d.ShareParent.ValueBool()
+		p.ShareParent = &shareParent
+	}
+	if !d.PidMode.IsUnknown() {
+		p.Pid = d.PidMode.ValueString()
+	}
+}
+
+// toPodmanPodNamespaceSpec adds the namespace modes not mapped by the pod create options
+func toPodmanPodNamespaceSpec(d podResourceData, sp *specgen.PodSpecGenerator, diags *diag.Diagnostics) {
+	if d.UtsMode.IsNull() || d.UtsMode.IsUnknown() {
+		return
+	}
+	ns, err := specgen.ParseNamespace(d.UtsMode.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("uts_mode"),
+			"Invalid UTS mode",
+			fmt.Sprintf("Cannot parse UTS mode: %s", err.Error()),
+		)
+		return
+	}
+	sp.UtsNs = ns
+}
+
+// fromPodmanPodShare converts the shared namespaces of the pod
+func fromPodmanPodShare(shared []string, diags *diag.Diagnostics) types.Set {
+	elems := make([]attr.Value, 0, len(shared))
+	sorted := append([]string{}, shared...)
+	sort.Strings(sorted)
+	for _, ns := range sorted {
+		// the user namespace is reported if the pod user is used, it cannot be configured
+		for _, shareable := range podShareableNamespaces {
+			if ns == shareable {
+				elems = append(elems, types.StringValue(ns))
+				break
+			}
+		}
+	}
+	set, d := types.SetValue(types.StringType, elems)
+	diags.Append(d...)
+	return set
+}
+
+// fromPodmanPodNamespaceMode converts the namespace mode of the infra container
+func fromPodmanPodNamespaceMode(mode string) types.String {
+	if mode == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(mode)
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	ntypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/modifier"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+var (
+	// podNetworkModes are the network modes configurable instead of networks
+	podNetworkModes = []string{
+		string(specgen.Host),
+		string(specgen.NoNetwork),
+		string(specgen.Slirp),
+		string(specgen.Pasta),
+	}
+	// podNetworkModesWithOptions are the network modes supporting options
+	podNetworkModesWithOptions = []string{
+		string(specgen.Slirp),
+		string(specgen.Pasta),
+	}
+)
+
+type (
+	// podNetworkData attaches the pod to a network
+	podNetworkData struct {
+		Name          types.String `tfsdk:"name"`
+		IPv4Address   types.String `tfsdk:"ipv4_address"`
+		IPv6Address   types.String `tfsdk:"ipv6_address"`
+		MacAddress    types.String `tfsdk:"mac_address"`
+		InterfaceName types.String `tfsdk:"interface_name"`
+		Aliases       types.Set    `tfsdk:"aliases"`
+	}
+)
+
+func podNetworksSchema() schema.Attribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Networks the pod is attached to. " +
+			"Attaches the pod to the default network if not set and no `network_mode` is configured.",
+		Optional: true,
+		Computed: true,
+		Validators: []validator.Set{
+			setvalidator.ConflictsWith(path.MatchRoot("network_mode")),
+		},
+		PlanModifiers: []planmodifier.Set{
+			setplanmodifier.UseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the network.",
+					Required:            true,
+				},
+				"ipv4_address": schema.StringAttribute{
+					MarkdownDescription: "Static IPv4 address of the pod in the network.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.IsIPv4Address(),
+					},
+				},
+				"ipv6_address": schema.StringAttribute{
+					MarkdownDescription: "Static IPv6 address of the pod in the network.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.IsIPv6Address(),
+					},
+				},
+				"mac_address": schema.StringAttribute{
+					MarkdownDescription: "Static MAC address of the pod in the network.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.IsMACAddress(),
+					},
+				},
+				"interface_name": schema.StringAttribute{
+					MarkdownDescription: "Name of the network interface in the pod. Podman assigns `ethX` if not set.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.MatchNetworkInterfaceName(),
+					},
+				},
+				"aliases": schema.SetAttribute{
+					MarkdownDescription: "DNS names the pod can be resolved with in the network.",
+					Optional:            true,
+					ElementType:         types.StringType,
+					Validators: []validator.Set{
+						setvalidator.SizeAtLeast(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+func podNetworkModeSchema() schema.Attribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"Network mode of the pod, one of `%s`. Cannot be used together with `networks`. "+
+				"Reports `%s` if the pod is attached to networks.",
+			strings.Join(podNetworkModes, "`, `"),
+			specgen.Bridge,
+		),
+		Optional: true,
+		Computed: true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(podNetworkModes...),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+	}
+}
+
+func podNetworkModeOptionsSchema() schema.Attribute {
+	return schema.ListAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"Options of the network mode, only supported by the network modes `%s`.",
+			strings.Join(podNetworkModesWithOptions, "`, `"),
+		),
+		Optional:    true,
+		ElementType: types.StringType,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.AlsoRequires(path.MatchRoot("network_mode")),
+		},
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// validatePodNetworkMode ensures the network mode options and ports are supported by the network mode
+func validatePodNetworkMode(mode types.String, options types.List, ports types.Set, diags *diag.Diagnostics) {
+	if mode.IsNull() || mode.IsUnknown() {
+		return
+	}
+
+	if !options.IsNull() && !options.IsUnknown() && !podNetworkModeSupportsOptions(mode.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("network_mode_options"),
+			"Unsupported network mode options",
+			fmt.Sprintf("The network mode %q does not support options.", mode.ValueString()),
+		)
+	}
+
+	switch specgen.NamespaceMode(mode.ValueString()) {
+	case specgen.Host, specgen.NoNetwork:
+		if !ports.IsNull() && !ports.IsUnknown() && len(ports.Elements()) > 0 {
+			diags.AddAttributeError(
+				path.Root("ports"),
+				"Unsupported ports",
+				fmt.Sprintf("Ports cannot be published with the network mode %q.", mode.ValueString()),
+			)
+		}
+	}
+}
+
+// podNetworkModeSupportsOptions checks if the network mode accepts options
+func podNetworkModeSupportsOptions(mode string) bool {
+	for _, m := range podNetworkModesWithOptions {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// toPodmanPodNetworkConfig adds the networks or network mode to the pod spec
+func toPodmanPodNetworkConfig(ctx context.Context, d podResourceData, sp *specgen.PodSpecGenerator, diags *diag.Diagnostics) {
+	if !d.NetworkMode.IsNull() && !d.NetworkMode.IsUnknown() {
+		mode := d.NetworkMode.ValueString()
+		sp.NetNS = specgen.Namespace{NSMode: specgen.NamespaceMode(mode)}
+		if !d.NetworkModeOptions.IsNull() {
+			var options []string
+			diags.Append(d.NetworkModeOptions.ElementsAs(ctx, &options, false)...)
+			sp.NetworkOptions = map[string][]string{mode: options}
+		}
+		return
+	}
+
+	if len(d.Networks) == 0 {
+		return
+	}
+	sp.NetNS = specgen.Namespace{NSMode: specgen.Bridge}
+	sp.Networks = make(map[string]ntypes.PerNetworkOptions, len(d.Networks))
+	for _, n := range d.Networks {
+		opts := ntypes.PerNetworkOptions{
+			InterfaceName: n.InterfaceName.ValueString(),
+		}
+		for _, ip := range []types.String{n.IPv4Address, n.IPv6Address} {
+			if !ip.IsNull() {
+				opts.StaticIPs = append(opts.StaticIPs, net.ParseIP(ip.ValueString()))
+			}
+		}
+		if !n.MacAddress.IsNull() {
+			mac, err := net.ParseMAC(n.MacAddress.ValueString())
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("networks"),
+					"Invalid MAC address",
+					fmt.Sprintf("Cannot parse MAC address of network %s: %s", n.Name.ValueString(), err.Error()),
+				)
+			}
+			opts.StaticMAC = ntypes.HardwareAddr(mac)
+		}
+		if !n.Aliases.IsNull() {
+			diags.Append(n.Aliases.ElementsAs(ctx, &opts.Aliases, false)...)
+		}
+		sp.Networks[n.Name.ValueString()] = opts
+	}
+}
+
+// fromPodmanPodNetworks converts the networks of the infra container to networks.
+// Podman does not distinguish static from assigned addresses, the prior networks select the reported values:
+// configured values are read back, unset values stay unset. Networks without prior data (e.g. on import)
+// take all reported values. The interface name is not reported and kept from the prior networks.
+func fromPodmanPodNetworks(names []string, infra *define.InspectContainerData, prior []podNetworkData, diags *diag.Diagnostics) []podNetworkData {
+	if len(names) == 0 {
+		return nil
+	}
+	networks := make([]podNetworkData, 0, len(names))
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+
+	var settings map[string]*define.InspectAdditionalNetwork
+	if infra != nil && infra.NetworkSettings != nil {
+		settings = infra.NetworkSettings.Networks
+	}
+
+	for _, name := range sorted {
+		network := podNetworkData{
+			Name:          types.StringValue(name),
+			IPv4Address:   types.StringNull(),
+			IPv6Address:   types.StringNull(),
+			MacAddress:    types.StringNull(),
+			InterfaceName: types.StringNull(),
+			Aliases:       types.SetNull(types.StringType),
+		}
+		known := false
+		for _, p := range prior {
+			if p.Name.ValueString() == name {
+				network = p
+				known = true
+				break
+			}
+		}
+
+		reported, ok := settings[name]
+		if !ok || reported == nil {
+			networks = append(networks, network)
+			continue
+		}
+		network.IPv4Address = readPodNetworkValue(network.IPv4Address, reported.IPAddress, known, equalIP)
+		network.IPv6Address = readPodNetworkValue(network.IPv6Address, reported.GlobalIPv6Address, known, equalIP)
+		network.MacAddress = readPodNetworkValue(network.MacAddress, reported.MacAddress, known, equalMAC)
+		if !known || !network.Aliases.IsNull() {
+			network.Aliases = podNetworkAliases(reported.Aliases, infra.ID, diags)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// readPodNetworkValue returns the reported value if the prior value is configured or unknown to the prior networks,
+// a semantically equal value keeps the representation of the prior value
+func readPodNetworkValue(prior types.String, value string, known bool, equal func(a, b string) bool) types.String {
+	switch {
+	case known && prior.IsNull():
+		return prior
+	case value == "":
+		return types.StringNull()
+	case !prior.IsNull() && !prior.IsUnknown() && equal(prior.ValueString(), value):
+		return prior
+	}
+	return types.StringValue(value)
+}
+
+func equalIP(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	return ipA != nil && ipA.Equal(ipB)
+}
+
+func equalMAC(a, b string) bool {
+	macA, errA := net.ParseMAC(a)
+	macB, errB := net.ParseMAC(b)
+	return errA == nil && errB == nil && macA.String() == macB.String()
+}
+
+// podNetworkAliases converts the reported aliases without the short container id added by podman
+func podNetworkAliases(aliases []string, containerID string, diags *diag.Diagnostics) types.Set {
+	elems := make([]attr.Value, 0, len(aliases))
+	for _, a := range aliases {
+		if len(containerID) >= 12 && a == containerID[:12] {
+			continue
+		}
+		elems = append(elems, types.StringValue(a))
+	}
+	if len(elems) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	set, d := types.SetValue(types.StringType, elems)
+	diags.Append(d...)
+	return set
+}
+
+// fromPodmanPodNetworkMode converts the network mode of the infra container
+func fromPodmanPodNetworkMode(infra *define.InspectContainerData, options map[string][]string, diags *diag.Diagnostics) (types.String, types.List) {
+	if infra == nil || infra.HostConfig == nil {
+		return types.StringNull(), types.ListNull(types.StringType)
+	}
+	// modes may be reported with their value, e.g. ns:/path
+	mode, _, _ := strings.Cut(infra.HostConfig.NetworkMode, ":")
+
+	modeOptions := types.ListNull(types.StringType)
+	if len(options[mode]) > 0 {
+		elems := make([]attr.Value, 0, len(options[mode]))
+		for _, o := range options[mode] {
+			elems = append(elems, types.StringValue(o))
+		}
+		list, d := types.ListValue(types.StringType, elems)
+		diags.Append(d...)
+		modeOptions = list
+	}
+	return types.StringValue(mode), modeOptions
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ntypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+const (
+	podPortProtocolDefault = "tcp"
+)
+
+var (
+	podPortProtocols = []string{"tcp", "udp", "sctp"}
+)
+
+type (
+	// podPortData publishes a port of the pod on the host
+	podPortData struct {
+		HostIP        types.String `tfsdk:"host_ip"`
+		HostPort      types.Int64  `tfsdk:"host_port"`
+		ContainerPort types.Int64  `tfsdk:"container_port"`
+		Protocol      types.String `tfsdk:"protocol"`
+		Range         types.Int64  `tfsdk:"range"`
+	}
+)
+
+func podPortsSchema() schema.Attribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Ports published by the infra container of the pod. " +
+			"A host port and protocol can only be published once.",
+		Optional: true,
+		Validators: []validator.Set{
+			validators.UniqueHostPorts(),
+		},
+		PlanModifiers: []planmodifier.Set{
+			setplanmodifier.RequiresReplace(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"host_ip": schema.StringAttribute{
+					MarkdownDescription: "IP on the host the port is bound to. Binds to all IPs if not set.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.IsIpAdress(),
+					},
+				},
+				"host_port": schema.Int64Attribute{
+					MarkdownDescription: "Port on the host. Podman assigns a random port if not set.",
+					Optional:            true,
+					Computed:            true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, 65535),
+					},
+				},
+				"container_port": schema.Int64Attribute{
+					MarkdownDescription: "Port of the pod.",
+					Required:            true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, 65535),
+					},
+				},
+				"protocol": schema.StringAttribute{
+					MarkdownDescription: fmt.Sprintf(
+						"Protocol of the port, one of `%s`. Defaults to `%s`.",
+						strings.Join(podPortProtocols, "`, `"),
+						podPortProtocolDefault,
+					),
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(podPortProtocols...),
+					},
+				},
+				"range": schema.Int64Attribute{
+					MarkdownDescription: "Number of ports published, counting up from the host and container port. Defaults to `1`.",
+					Optional:            true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, 65535),
+					},
+				},
+			},
+		},
+	}
+}
+
+// protocol returns the configured or default protocol
+func (p podPortData) protocol() string {
+	if p.Protocol.IsNull() {
+		return podPortProtocolDefault
+	}
+	return p.Protocol.ValueString()
+}
+
+// portRange returns the configured or default range
+func (p podPortData) portRange() int64 {
+	if p.Range.IsNull() {
+		return 1
+	}
+	return p.Range.ValueInt64()
+}
+
+// toPodmanPortMappings converts the ports to podman port mappings
+func toPodmanPortMappings(ports []podPortData) []ntypes.PortMapping {
+	mappings := make([]ntypes.PortMapping, 0, len(ports))
+	for _, p := range ports {
+		mappings = append(mappings, ntypes.PortMapping{
+			HostIP:        p.HostIP.ValueString(),
+			HostPort:      uint16(p.HostPort.ValueInt64()),
+			ContainerPort: uint16(p.ContainerPort.ValueInt64()),
+			Protocol:      p.protocol(),
+			Range:         uint16(p.portRange()),
+		})
+	}
+	return mappings
+}
+
+// fromPodmanPortBindings converts the inspected port bindings to ports.
+// The bindings are expanded per port by podman, known ports keep the representation
+// of the prior ports and assigned host ports are filled in. Other bindings are merged to ranges.
+func fromPodmanPortBindings(bindings map[string][]define.InspectHostPort, prior []podPortData) []podPortData {
+	ports := make([]podPortData, 0)
+	bindings = clonePortBindings(bindings)
+
+	// takeBinding removes and returns the host port bound to the host ip
+	takeBinding := func(key string, hostIP string, hostPort int64) (int64, bool) {
+		for i, b := range bindings[key] {
+			port, err := strconv.ParseInt(b.HostPort, 10, 64)
+			if err != nil || b.HostIP != hostIP || (hostPort != 0 && port != hostPort) {
+				continue
+			}
+			bindings[key] = append(bindings[key][:i:i], bindings[key][i+1:]...)
+			return port, true
+		}
+		return 0, false
+	}
+
+	for _, p := range prior {
+		unmatched := clonePortBindings(bindings)
+		hostPort := p.HostPort.ValueInt64()
+		matched := true
+		for i := int64(0); i < p.portRange(); i++ {
+			key := fmt.Sprintf("%d/%s", p.ContainerPort.ValueInt64()+i, p.protocol())
+			expected := int64(0)
+			if hostPort != 0 {
+				expected = hostPort + i
+			}
+			port, ok := takeBinding(key, p.HostIP.ValueString(), expected)
+			if !ok {
+				matched = false
+				break
+			}
+			if i == 0 {
+				hostPort = port
+			}
+		}
+		if !matched {
+			bindings = unmatched
+			continue
+		}
+		p.HostPort = types.Int64Value(hostPort)
+		ports = append(ports, p)
+	}
+
+	// remaining bindings are not known by the prior ports
+	ports = append(ports, mergePortBindings(bindings)...)
+
+	if len(ports) == 0 {
+		return nil
+	}
+	return ports
+}
+
+// mergePortBindings converts the port bindings to ports,
+// contiguous container and host ports of the same host ip and protocol are merged to a range.
+func mergePortBindings(bindings map[string][]define.InspectHostPort) []podPortData {
+	type binding struct {
+		hostIP        string
+		hostPort      int64
+		containerPort int64
+		protocol      string
+	}
+
+	flat := make([]binding, 0, len(bindings))
+	for key, hostPorts := range bindings {
+		containerPort, protocol, _ := strings.Cut(key, "/")
+		cp, err := strconv.ParseInt(containerPort, 10, 64)
+		if err != nil {
+			continue
+		}
+		for _, b := range hostPorts {
+			hp, err := strconv.ParseInt(b.HostPort, 10, 64)
+			if err != nil {
+				continue
+			}
+			flat = append(flat, binding{hostIP: b.HostIP, hostPort: hp, containerPort: cp, protocol: protocol})
+		}
+	}
+	sort.Slice(flat, func(i, j int) bool {
+		a, b := flat[i], flat[j]
+		if a.protocol != b.protocol {
+			return a.protocol < b.protocol
+		}
+		if a.hostIP != b.hostIP {
+			return a.hostIP < b.hostIP
+		}
+		if a.containerPort != b.containerPort {
+			return a.containerPort < b.containerPort
+		}
+		return a.hostPort < b.hostPort
+	})
+
+	ports := make([]podPortData, 0, len(flat))
+	var last binding
+	var portRange int64
+	for i, b := range flat {
+		if i > 0 && b.protocol == last.protocol && b.hostIP == last.hostIP &&
+			b.containerPort == last.containerPort+portRange && b.hostPort == last.hostPort+portRange {
+			portRange++
+			ports[len(ports)-1].Range = types.Int64Value(portRange)
+			continue
+		}
+		last, portRange = b, 1
+
+		port := podPortData{
+			HostIP:        types.StringNull(),
+			HostPort:      types.Int64Value(b.hostPort),
+			ContainerPort: types.Int64Value(b.containerPort),
+			Protocol:      types.StringNull(),
+			Range:         types.Int64Null(),
+		}
+		if b.hostIP != "" {
+			port.HostIP = types.StringValue(b.hostIP)
+		}
+		if b.protocol != podPortProtocolDefault {
+			port.Protocol = types.StringValue(b.protocol)
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// clonePortBindings copies the port bindings to modify them
+func clonePortBindings(bindings map[string][]define.InspectHostPort) map[string][]define.InspectHostPort {
+	c := make(map[string][]define.InspectHostPort, len(bindings))
+	for key, b := range bindings {
+		c[key] = append([]define.InspectHostPort{}, b...)
+	}
+	return c
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFromPodmanPortBindings(t *testing.T) {
+	port := func(hostIP string, hostPort, containerPort int64, protocol string, portRange int64) podPortData {
+		p := podPortData{
+			HostIP:        types.StringNull(),
+			HostPort:      types.Int64Value(hostPort),
+			ContainerPort: types.Int64Value(containerPort),
+			Protocol:      types.StringNull(),
+			Range:         types.Int64Null(),
+		}
+		if hostIP != "" {
+			p.HostIP = types.StringValue(hostIP)
+		}
+		if protocol != "" {
+			p.Protocol = types.StringValue(protocol)
+		}
+		if portRange != 0 {
+			p.Range = types.Int64Value(portRange)
+		}
+		return p
+	}
+	hostPort := func(hostIP, port string) []define.InspectHostPort {
+		return []define.InspectHostPort{{HostIP: hostIP, HostPort: port}}
+	}
+	udpRange := map[string][]define.InspectHostPort{
+		"9000/udp": hostPort("127.0.0.1", "18100"),
+		"9001/udp": hostPort("127.0.0.1", "18101"),
+		"9002/udp": hostPort("127.0.0.1", "18102"),
+	}
+
+	tests := []struct {
+		desc     string
+		bindings map[string][]define.InspectHostPort
+		prior    []podPortData
+		want     []podPortData
+	}{
+		{
+			desc: "No bindings",
+			want: nil,
+		},
+		{
+			desc:     "Single port",
+			bindings: map[string][]define.InspectHostPort{"80/tcp": hostPort("", "18080")},
+			want:     []podPortData{port("", 18080, 80, "", 0)},
+		},
+		{
+			desc:     "Contiguous ports are merged to a range",
+			bindings: udpRange,
+			want:     []podPortData{port("127.0.0.1", 18100, 9000, "udp", 3)},
+		},
+		{
+			desc: "Ports with different host ips, protocols or gaps are not merged",
+			bindings: map[string][]define.InspectHostPort{
+				"80/tcp": hostPort("", "18080"),
+				"81/tcp": hostPort("127.0.0.1", "18081"),
+				"82/udp": hostPort("", "18082"),
+				"83/tcp": hostPort("", "18084"),
+			},
+			want: []podPortData{
+				port("", 18080, 80, "", 0),
+				port("", 18084, 83, "", 0),
+				port("127.0.0.1", 18081, 81, "", 0),
+				port("", 18082, 82, "udp", 0),
+			},
+		},
+		{
+			desc:     "Prior range keeps its representation",
+			bindings: udpRange,
+			prior:    []podPortData{port("127.0.0.1", 18100, 9000, "udp", 3)},
+			want:     []podPortData{port("127.0.0.1", 18100, 9000, "udp", 3)},
+		},
+		{
+			desc: "Assigned host port of a prior port is filled in",
+			bindings: map[string][]define.InspectHostPort{
+				"443/tcp": hostPort("", "40000"),
+			},
+			prior: []podPortData{{
+				HostIP:        types.StringNull(),
+				HostPort:      types.Int64Unknown(),
+				ContainerPort: types.Int64Value(443),
+				Protocol:      types.StringNull(),
+				Range:         types.Int64Null(),
+			}},
+			want: []podPortData{port("", 40000, 443, "", 0)},
+		},
+		{
+			desc: "Bindings not known by prior ports are added",
+			bindings: map[string][]define.InspectHostPort{
+				"80/tcp":   hostPort("", "18080"),
+				"9000/udp": hostPort("127.0.0.1", "18100"),
+				"9001/udp": hostPort("127.0.0.1", "18101"),
+			},
+			prior: []podPortData{port("", 18080, 80, "", 0)},
+			want: []podPortData{
+				port("", 18080, 80, "", 0),
+				port("127.0.0.1", 18100, 9000, "udp", 2),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := fromPodmanPortBindings(test.bindings, test.prior)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+			}
+		})
+	}
+}
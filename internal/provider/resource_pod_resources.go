@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/docker/go-units"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/project0/terraform-provider-podman/internal/modifier"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+const (
+	// podMemorySwapUnlimited allows unlimited swap usage
+	podMemorySwapUnlimited = "-1"
+)
+
+func podResourcesSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"cpus": schema.Float64Attribute{
+			MarkdownDescription: "Number of CPUs the pod can use, e.g. `1.5`.",
+			Optional:            true,
+			Validators: []validator.Float64{
+				float64validator.AtLeast(0.01),
+			},
+			PlanModifiers: []planmodifier.Float64{
+				float64planmodifier.RequiresReplace(),
+			},
+		},
+		"cpuset_cpus": schema.StringAttribute{
+			MarkdownDescription: "CPUs the pod is allowed to run on, e.g. `0-3,5`.",
+			Optional:            true,
+			Validators: []validator.String{
+				validators.MatchCPUSet(),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"cpuset_mems": schema.StringAttribute{
+			MarkdownDescription: "Memory nodes the pod is allowed to use, e.g. `0-1`. Only effective on NUMA systems.",
+			Optional:            true,
+			Validators: []validator.String{
+				validators.MatchCPUSet(),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"cpu_shares": schema.Int64Attribute{
+			MarkdownDescription: "Relative CPU weight of the pod compared to other pods and containers.",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(2, 262144),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+		},
+		"memory": schema.StringAttribute{
+			MarkdownDescription: "Memory limit of the pod with an optional unit (`b`, `k`, `m`, `g`), e.g. `512m`.",
+			Optional:            true,
+			Validators: []validator.String{
+				validators.IsByteSize(),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"memory_swap": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf(
+				"Limit of memory and swap of the pod with an optional unit (`b`, `k`, `m`, `g`), `%s` allows unlimited swap. "+
+					"Requires `memory` to be set.",
+				podMemorySwapUnlimited,
+			),
+			Optional: true,
+			Computed: true,
+			Validators: []validator.String{
+				stringvalidator.Any(
+					validators.IsByteSize(),
+					stringvalidator.OneOf(podMemorySwapUnlimited),
+				),
+				stringvalidator.AlsoRequires(path.MatchRoot("memory")),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				modifier.RequiresReplaceComputed(),
+			},
+		},
+		"pids_limit": schema.Int64Attribute{
+			MarkdownDescription: "Maximum number of processes of the pod, `-1` for unlimited. " +
+				"The limit is not reported by podman, changes outside of terraform are not detected.",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(-1),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+		},
+		"blkio_weight": schema.Int64Attribute{
+			MarkdownDescription: "Relative block IO weight of the pod.",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(10, 1000),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+// toPodmanPodResourceOptions adds the cpu limits handled by the pod create options
+func toPodmanPodResourceOptions(d podResourceData, p *entities.PodCreateOptions) {
+	p.Cpus = d.Cpus.ValueFloat64()
+	p.CpusetCpus = d.CpusetCpus.ValueString()
+}
+
+// toPodmanPodResourceLimits adds the remaining resource limits to the pod spec
+func toPodmanPodResourceLimits(d podResourceData, sp *specgen.PodSpecGenerator, diags *diag.Diagnostics) {
+	if sp.ResourceLimits == nil {
+		sp.ResourceLimits = &specs.LinuxResources{}
+	}
+	limits := sp.ResourceLimits
+	if limits.CPU == nil {
+		limits.CPU = &specs.LinuxCPU{}
+	}
+
+	if !d.CpusetMems.IsNull() {
+		limits.CPU.Mems = d.CpusetMems.ValueString()
+	}
+	if !d.CPUShares.IsNull() {
+		shares := uint64(d.CPUShares.ValueInt64())
+		limits.CPU.Shares = &shares
+	}
+
+	if !d.Memory.IsNull() {
+		memory := parsePodByteSize(path.Root("memory"), d.Memory, diags)
+		limits.Memory = &specs.LinuxMemory{Limit: &memory}
+		if !d.MemorySwap.IsNull() && !d.MemorySwap.IsUnknown() {
+			swap := int64(-1)
+			if d.MemorySwap.ValueString() != podMemorySwapUnlimited {
+				swap = parsePodByteSize(path.Root("memory_swap"), d.MemorySwap, diags)
+			}
+			limits.Memory.Swap = &swap
+		}
+	}
+
+	if !d.PidsLimit.IsNull() {
+		limits.Pids = &specs.LinuxPids{Limit: d.PidsLimit.ValueInt64()}
+	}
+
+	if !d.BlkioWeight.IsNull() {
+		weight := uint16(d.BlkioWeight.ValueInt64())
+		limits.BlockIO = &specs.LinuxBlockIO{Weight: &weight}
+	}
+}
+
+// parsePodByteSize parses a size with an optional unit
+func parsePodByteSize(p path.Path, value types.String, diags *diag.Diagnostics) int64 {
+	size, err := units.RAMInBytes(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid size", fmt.Sprintf("Cannot parse size %s: %s", value.String(), err.Error()))
+	}
+	return size
+}
+
+// fromPodmanPodResources converts the resource limits of the pod.
+// Sizes and cpus keep the prior representation if they result in the same limit.
+// The pids limit is applied to the pod cgroup and not reported by podman, it is kept from the prior data.
+func fromPodmanPodResources(p *entities.PodInspectReport, prior podResourceData, state *podResourceData) {
+	state.Cpus = fromPodmanPodCpus(p.CPUPeriod, p.CPUQuota, prior.Cpus)
+	state.CpusetCpus = fromPodmanPodResourceString(p.CPUSetCPUs)
+	state.CpusetMems = fromPodmanPodResourceString(p.CPUSetMems)
+	state.CPUShares = fromPodmanPodResourceInt(p.CPUShares)
+	state.BlkioWeight = fromPodmanPodResourceInt(p.BlkioWeight)
+	state.PidsLimit = prior.PidsLimit
+
+	state.Memory = fromPodmanPodByteSize(p.MemoryLimit, prior.Memory)
+	state.MemorySwap = fromPodmanPodByteSize(p.MemorySwap, prior.MemorySwap)
+	if int64(p.MemorySwap) == -1 {
+		state.MemorySwap = types.StringValue(podMemorySwapUnlimited)
+	}
+}
+
+// fromPodmanPodCpus converts the cpu quota to cores,
+// the prior value is kept if it results in the same quota as the fraction cannot be represented exactly.
+func fromPodmanPodCpus(period uint64, quota int64, prior types.Float64) types.Float64 {
+	if period == 0 || quota <= 0 {
+		return types.Float64Null()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() && int64(prior.ValueFloat64()*float64(period)) == quota {
+		return prior
+	}
+	return types.Float64Value(float64(quota) / float64(period))
+}
+
+// fromPodmanPodResourceString converts an optional string, empty values are not set
+func fromPodmanPodResourceString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// fromPodmanPodResourceInt converts an optional number, zero values are not set
+func fromPodmanPodResourceInt(value uint64) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(value))
+}
+
+// fromPodmanPodByteSize converts a size in bytes, the prior value is kept if it matches the size
+func fromPodmanPodByteSize(size uint64, prior types.String) types.String {
+	if size == 0 {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if priorSize, err := units.RAMInBytes(prior.ValueString()); err == nil && uint64(priorSize) == size {
+			return prior
+		}
+	}
+	return types.StringValue(strconv.FormatUint(size, 10))
+}
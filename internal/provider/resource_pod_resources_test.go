@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFromPodmanPodCpus(t *testing.T) {
+	tests := []struct {
+		desc   string
+		period uint64
+		quota  int64
+		prior  types.Float64
+		want   types.Float64
+	}{
+		{
+			desc:  "No quota",
+			prior: types.Float64Null(),
+			want:  types.Float64Null(),
+		},
+		{
+			desc:   "Quota is converted to cores",
+			period: 100000,
+			quota:  150000,
+			prior:  types.Float64Null(),
+			want:   types.Float64Value(1.5),
+		},
+		{
+			desc:   "Prior value with the same quota is kept",
+			period: 100000,
+			quota:  33333,
+			prior:  types.Float64Value(0.333333),
+			want:   types.Float64Value(0.333333),
+		},
+		{
+			desc:   "Prior value with a different quota is replaced",
+			period: 100000,
+			quota:  50000,
+			prior:  types.Float64Value(0.333333),
+			want:   types.Float64Value(0.5),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := fromPodmanPodCpus(test.period, test.quota, test.prior); !got.Equal(test.want) {
+				t.Errorf("%s: got %s, want %s", test.desc, got, test.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourcePod_basic(t *testing.T) {
@@ -81,6 +83,760 @@ func TestAccResourcePod_volume(t *testing.T) {
 	})
 }
 
+func TestAccResourcePod_ports(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Duplicate host ports are rejected
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  ports = [
+    {
+      host_port      = 18080
+      container_port = 80
+    },
+    {
+      host_port      = 18075
+      container_port = 8000
+      range          = 10
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Duplicate host port"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodPorts(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "ports.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "ports.*", map[string]string{
+						"host_port":      "18080",
+						"container_port": "80",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "ports.*", map[string]string{
+						"host_ip":        "127.0.0.1",
+						"host_port":      "18100",
+						"container_port": "9000",
+						"protocol":       "udp",
+						"range":          "5",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "ports.*", map[string]string{
+						"container_port": "443",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Random host ports are kept
+			{
+				Config:   testAccResourcePodPorts(name),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_networks(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Network mode options are rejected for the host network
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name                 = %[1]q
+  network_mode         = "host"
+  network_mode_options = ["mtu=1500"]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported network mode options"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodNetworks(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "network_mode", "bridge"),
+					resource.TestCheckResourceAttr("podman_pod.test", "networks.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "networks.*", map[string]string{
+						"name":           name,
+						"ipv4_address":   "10.89.100.10",
+						"ipv6_address":   "fd00:100::10",
+						"mac_address":    "92:d0:c6:0a:29:33",
+						"interface_name": "eth10",
+						"aliases.#":      "1",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "networks.*", map[string]string{
+						"name": name + "-2",
+					}),
+				),
+			},
+			// Static network configuration is kept
+			{
+				Config:   testAccResourcePodNetworks(name),
+				PlanOnly: true,
+			},
+			// Static network configuration is imported, the interface name is not reported by podman
+			{
+				Config: testAccResourcePodNetworksStatic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "networks.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test replace with a network mode
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name         = %[1]q
+  network_mode = "none"
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "network_mode", "none"),
+					resource.TestCheckNoResourceAttr("podman_pod.test", "networks"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_namespaces(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with the default namespaces
+			{
+				Config: testAccResourcePod(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "share.#", "3"),
+					resource.TestCheckTypeSetElemAttr("podman_pod.test", "share.*", "ipc"),
+					resource.TestCheckTypeSetElemAttr("podman_pod.test", "share.*", "net"),
+					resource.TestCheckTypeSetElemAttr("podman_pod.test", "share.*", "uts"),
+					resource.TestCheckResourceAttr("podman_pod.test", "pid_mode", "private"),
+					resource.TestCheckResourceAttr("podman_pod.test", "uts_mode", "private"),
+				),
+			},
+			// Test replace with shared host namespaces
+			{
+				Config: testAccResourcePodNamespaces(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "share.#", "3"),
+					resource.TestCheckTypeSetElemAttr("podman_pod.test", "share.*", "net"),
+					resource.TestCheckTypeSetElemAttr("podman_pod.test", "share.*", "pid"),
+					resource.TestCheckTypeSetElemAttr("podman_pod.test", "share.*", "uts"),
+					resource.TestCheckResourceAttr("podman_pod.test", "share_parent", "false"),
+					resource.TestCheckResourceAttr("podman_pod.test", "pid_mode", "host"),
+					resource.TestCheckResourceAttr("podman_pod.test", "uts_mode", "host"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "podman_pod.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"share_parent"},
+			},
+			// Test replace without shared namespaces
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name  = %[1]q
+  share = []
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "share.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_infra(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Ports require an infra container
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name  = %[1]q
+  infra = false
+  ports = [
+    {
+      container_port = 80
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing infra container"),
+			},
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name          = %[1]q
+  infra_name    = "%[1]s-infra"
+  infra_command = ["/catatonit", "-P"]
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "infra", "true"),
+					resource.TestCheckResourceAttr("podman_pod.test", "infra_name", name+"-infra"),
+					resource.TestCheckResourceAttrSet("podman_pod.test", "infra_image"),
+					resource.TestCheckResourceAttrSet("podman_pod.test", "infra_container_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "podman_pod.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"infra_command"},
+			},
+			// Test replace without infra container
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name  = %[1]q
+  infra = false
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "infra", "false"),
+					resource.TestCheckNoResourceAttr("podman_pod.test", "infra_image"),
+					resource.TestCheckNoResourceAttr("podman_pod.test", "infra_container_id"),
+					resource.TestCheckResourceAttr("podman_pod.test", "share.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_resources(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid sizes are rejected
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name   = %[1]q
+  memory = "512x"
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Failed to parse size"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodResources(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "cpus", "1.5"),
+					resource.TestCheckResourceAttr("podman_pod.test", "cpuset_cpus", "0"),
+					resource.TestCheckResourceAttr("podman_pod.test", "cpu_shares", "512"),
+					resource.TestCheckResourceAttr("podman_pod.test", "memory", "512m"),
+					resource.TestCheckResourceAttr("podman_pod.test", "memory_swap", "1g"),
+					resource.TestCheckResourceAttr("podman_pod.test", "pids_limit", "100"),
+				),
+			},
+			// Sizes keep their representation
+			{
+				Config:   testAccResourcePodResources(name),
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the pids limit of the pod cgroup is not reported by podman
+				ImportStateVerifyIgnore: []string{"memory", "memory_swap", "pids_limit"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_nameResolution(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Hosts cannot be added to an unmanaged /etc/hosts
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name     = %[1]q
+  no_hosts = true
+  add_hosts = {
+    db = "192.0.2.10"
+  }
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported hosts"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodNameResolution(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "dns_servers.#", "2"),
+					resource.TestCheckResourceAttr("podman_pod.test", "dns_servers.0", "192.0.2.1"),
+					resource.TestCheckResourceAttr("podman_pod.test", "dns_servers.1", "2001:db8::1"),
+					resource.TestCheckResourceAttr("podman_pod.test", "dns_search.0", "example.com"),
+					resource.TestCheckResourceAttr("podman_pod.test", "dns_options.0", "ndots:2"),
+					resource.TestCheckResourceAttr("podman_pod.test", "add_hosts.%", "3"),
+					resource.TestCheckResourceAttr("podman_pod.test", "add_hosts.db", "192.0.2.10"),
+					resource.TestCheckResourceAttr("podman_pod.test", "add_hosts.database", "192.0.2.10"),
+					resource.TestCheckResourceAttr("podman_pod.test", "add_hosts.cache", "2001:db8::10"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test replace with an unmanaged /etc/hosts
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name     = %[1]q
+  no_hosts = true
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "no_hosts", "true"),
+					resource.TestCheckNoResourceAttr("podman_pod.test", "add_hosts"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_userns(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Modes and explicit mappings are exclusive
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  userns = {
+    mode    = "keep-id"
+    uid_map = ["0:100000:65536"]
+  }
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			// Options are only supported by their mode
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  userns = {
+    mode = "auto"
+    uid  = 1000
+  }
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported user namespace option"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodUserns(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "userns.mode", "host"),
+				),
+			},
+			{
+				Config: testAccResourcePodUsernsMapping(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("podman_pod.test", "userns.mode"),
+					resource.TestCheckResourceAttr("podman_pod.test", "userns.uid_map.0", "0:100000:65536"),
+					resource.TestCheckResourceAttr("podman_pod.test", "userns.gid_map.0", "0:100000:65536"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_tmpfs(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourcePodTmpfs(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "mounts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "mounts.*", map[string]string{
+						"destination":     "/scratch",
+						"tmpfs.size":      "64m",
+						"tmpfs.mode":      "1777",
+						"tmpfs.exec":      "false",
+						"tmpfs.read_only": "false",
+						"tmpfs.tmpcopyup": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "mounts.*", map[string]string{
+						"destination":     "/cache",
+						"tmpfs.tmpcopyup": "false",
+					}),
+				),
+			},
+			// Options keep their representation
+			{
+				Config:   testAccResourcePodTmpfs(name),
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_overlay(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Only one mount type can be configured
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  mounts = [
+    {
+      destination = "/data"
+      image = {
+        name = "alpine"
+      }
+      overlay = {
+        path = "/tmp"
+      }
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodOverlay(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "mounts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "mounts.*", map[string]string{
+						"destination":   "/data",
+						"overlay.path":  "/tmp",
+						"overlay.chown": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "mounts.*", map[string]string{
+						"destination":     "/image",
+						"image.name":      "docker.io/library/alpine:latest",
+						"image.read_only": "true",
+					}),
+				),
+			},
+			// Overlays are kept from the state
+			{
+				Config:   testAccResourcePodOverlay(name),
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_pod.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// overlays are not reported by podman and cannot be imported, the image mount is checked by ImportStateCheck
+				ImportStateVerifyIgnore: []string{"mounts"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if attrs["mounts.#"] != "1" || attrs["mounts.0.destination"] != "/image" ||
+						attrs["mounts.0.image.name"] != "docker.io/library/alpine:latest" {
+						return fmt.Errorf("expected only the image mount to be imported, got %v", attrs)
+					}
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_devices(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CDI devices do not support options of host devices
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  devices = [
+    {
+      path        = "vendor.com/class=name"
+      permissions = "r"
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported CDI device options"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodDevices(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "devices.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "devices.*", map[string]string{
+						"path": "/dev/null",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "devices.*", map[string]string{
+						"path":           "/dev/zero",
+						"container_path": "/dev/empty",
+						"permissions":    "r",
+					}),
+				),
+			},
+			// Devices keep their representation
+			{
+				Config:   testAccResourcePodDevices(name),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_mountStrings(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown options are rejected
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name          = %[1]q
+  mount_strings = ["data:/data:ro,unknown"]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`unknown mount option "unknown"`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourcePodMountStrings(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_pod.test", "mount_strings.#", "3"),
+					resource.TestCheckResourceAttr("podman_pod.test", "mount_strings.0", "type=bind,src=/tmp,dst=/host,ro,z"),
+					resource.TestCheckResourceAttr("podman_pod.test", "mounts.#", "1"),
+					resource.TestCheckResourceAttr("podman_pod.test", "mounts.0.destination", "/cache"),
+				),
+			},
+			// Mount strings are not reported as mounts
+			{
+				Config:   testAccResourcePodMountStrings(name),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourcePod_mountValidation(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Destinations and host paths must be absolute
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  mounts = [
+    {
+      destination = "data"
+      bind = {
+        path = "srv"
+      }
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be an absolute path"),
+			},
+			// Destinations must be unique
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  mounts = [
+    {
+      destination = "/data"
+      bind = {
+        path = "/srv"
+      }
+    },
+    {
+      destination = "/data"
+      tmpfs = {
+        size = "64m"
+      }
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Duplicate mount destination"),
+			},
+			// Destinations of the mount strings must not be used by the mounts
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name          = %[1]q
+  mount_strings = ["data:/data"]
+  mounts = [
+    {
+      destination = "/data"
+      bind = {
+        path = "/srv"
+      }
+    },
+  ]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Duplicate mount destination"),
+			},
+			// Bind options are only supported by bind mounts
+			{
+				Config: fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name          = %[1]q
+  mount_strings = ["type=volume,src=data,dst=/data,relabel=shared"]
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"relabel" is only supported by bind mounts`),
+			},
+		},
+	})
+}
+
+func TestAccResourcePod_mountDefaults(t *testing.T) {
+	name := generateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourcePodMountDefaults(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "mounts.*", map[string]string{
+						"destination":      "/host",
+						"bind.propagation": "rprivate",
+						"bind.recursive":   "true",
+						"bind.suid":        "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("podman_pod.test", "mounts.*", map[string]string{
+						"destination": "/scratch",
+						"tmpfs.size":  "1g",
+						"tmpfs.mode":  "0700",
+					}),
+				),
+			},
+			// Defaults and semantically equal values do not cause a diff
+			{
+				Config:   testAccResourcePodMountDefaults(name),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func testAccResourcePod(configurableAttribute string) string {
 	return fmt.Sprintf(`
 resource "podman_pod" "test" {
@@ -120,3 +876,275 @@ resource "podman_pod" "test" {
 }
 `, name)
 }
+
+func testAccResourcePodPorts(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  ports = [
+    {
+      host_port      = 18080
+      container_port = 80
+    },
+    {
+      host_ip        = "127.0.0.1"
+      host_port      = 18100
+      container_port = 9000
+      protocol       = "udp"
+      range          = 5
+    },
+    {
+      container_port = 443
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodNetworks(name string) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name = %[1]q
+  ipv6 = true
+  subnets = [
+    {
+      subnet = "10.89.100.0/24"
+    },
+    {
+      subnet = "fd00:100::/64"
+    },
+  ]
+}
+
+resource "podman_network" "test2" {
+  name = "%[1]s-2"
+}
+
+resource "podman_pod" "test" {
+  name = %[1]q
+  networks = [
+    {
+      name           = podman_network.test.name
+      ipv4_address   = "10.89.100.10"
+      ipv6_address   = "fd00:100::10"
+      mac_address    = "92:d0:c6:0a:29:33"
+      interface_name = "eth10"
+      aliases        = ["web"]
+    },
+    {
+      name = podman_network.test2.name
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodNetworksStatic(name string) string {
+	return fmt.Sprintf(`
+resource "podman_network" "test" {
+  name = %[1]q
+  ipv6 = true
+  subnets = [
+    {
+      subnet = "10.89.100.0/24"
+    },
+    {
+      subnet = "fd00:100::/64"
+    },
+  ]
+}
+
+resource "podman_pod" "test" {
+  name = %[1]q
+  networks = [
+    {
+      name         = podman_network.test.name
+      ipv4_address = "10.89.100.11"
+      ipv6_address = "fd00:100::11"
+      mac_address  = "92:d0:c6:0a:29:34"
+      aliases      = ["web"]
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodNamespaces(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name         = %[1]q
+  share        = ["net", "pid", "uts"]
+  share_parent = false
+  pid_mode     = "host"
+  uts_mode     = "host"
+}
+`, name)
+}
+
+func testAccResourcePodResources(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name        = %[1]q
+  cpus        = 1.5
+  cpuset_cpus = "0"
+  cpu_shares  = 512
+  memory      = "512m"
+  memory_swap = "1g"
+  pids_limit  = 100
+}
+`, name)
+}
+
+func testAccResourcePodNameResolution(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name        = %[1]q
+  dns_servers = ["192.0.2.1", "2001:db8::1"]
+  dns_search  = ["example.com"]
+  dns_options = ["ndots:2"]
+  add_hosts = {
+    db       = "192.0.2.10"
+    database = "192.0.2.10"
+    cache    = "2001:db8::10"
+  }
+}
+`, name)
+}
+
+func testAccResourcePodUserns(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  userns = {
+    mode = "host"
+  }
+}
+`, name)
+}
+
+func testAccResourcePodUsernsMapping(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  userns = {
+    uid_map = ["0:100000:65536"]
+    gid_map = ["0:100000:65536"]
+  }
+}
+`, name)
+}
+
+func testAccResourcePodTmpfs(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  mounts = [
+    {
+      destination = "/scratch"
+      tmpfs = {
+        size = "64m"
+        mode = "1777"
+        exec = false
+      }
+    },
+    {
+      destination = "/cache"
+      tmpfs = {
+        tmpcopyup = false
+      }
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodOverlay(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  mounts = [
+    {
+      destination = "/data"
+      overlay = {
+        path  = "/tmp"
+        chown = false
+      }
+    },
+    {
+      destination = "/image"
+      image = {
+        name = "docker.io/library/alpine:latest"
+      }
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodDevices(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  devices = [
+    {
+      path = "/dev/null"
+    },
+    {
+      path           = "/dev/zero"
+      container_path = "/dev/empty"
+      permissions    = "r"
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodMountStrings(name string) string {
+	return fmt.Sprintf(`
+resource "podman_volume" "test" {
+  name = %[1]q
+}
+
+resource "podman_pod" "test" {
+  name = %[1]q
+  mount_strings = [
+    "type=bind,src=/tmp,dst=/host,ro,z",
+    "${podman_volume.test.name}:/data:ro,U",
+    "type=tmpfs,dst=/scratch,tmpfs-size=64m",
+  ]
+  mounts = [
+    {
+      destination = "/cache"
+      tmpfs = {
+        tmpcopyup = false
+      }
+    },
+  ]
+}
+`, name)
+}
+
+func testAccResourcePodMountDefaults(name string) string {
+	return fmt.Sprintf(`
+resource "podman_pod" "test" {
+  name = %[1]q
+  mounts = [
+    {
+      destination = "/host"
+      bind = {
+        path        = "/tmp"
+        propagation = "rprivate"
+        recursive   = true
+      }
+    },
+    {
+      destination = "/scratch"
+      tmpfs = {
+        size = "1g"
+        mode = "0700"
+      }
+    },
+  ]
+}
+`, name)
+}
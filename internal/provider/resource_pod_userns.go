@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/namespaces"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+const (
+	podUsernsAuto   = "auto"
+	podUsernsKeepID = "keep-id"
+	podUsernsHost   = "host"
+	podUsernsNoMap  = "nomap"
+)
+
+var (
+	// podUsernsModes are the supported user namespace modes of a pod
+	podUsernsModes = []string{podUsernsAuto, podUsernsKeepID, podUsernsHost, podUsernsNoMap}
+)
+
+type (
+	// podUserNamespaceData configures the user namespace of the pod
+	podUserNamespaceData struct {
+		Mode types.String `tfsdk:"mode"`
+		Size types.Int64  `tfsdk:"size"`
+		UID  types.Int64  `tfsdk:"uid"`
+		GID  types.Int64  `tfsdk:"gid"`
+
+		UIDMap     types.List   `tfsdk:"uid_map"`
+		GIDMap     types.List   `tfsdk:"gid_map"`
+		SubUIDName types.String `tfsdk:"subuidname"`
+		SubGIDName types.String `tfsdk:"subgidname"`
+	}
+)
+
+func podUserNamespaceSchema() schema.Attribute {
+	// explicit mappings cannot be combined with a mode
+	modeConflict := path.MatchRelative().AtParent().AtName("mode")
+	idMap := func(kind string) schema.Attribute {
+		return schema.ListAttribute{
+			MarkdownDescription: fmt.Sprintf(
+				"%s mappings of the user namespace as `container_id:host_id:size`. Cannot be used with `mode`.",
+				kind,
+			),
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(validators.MatchIDMap()),
+				listvalidator.ConflictsWith(modeConflict),
+			},
+		}
+	}
+	subName := func(kind, file string) schema.Attribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf(
+				"Name of the %s ranges of the %s file used for the mapping. "+
+					"The file is read on the machine running terraform. Cannot be used with `mode`.",
+				kind, file,
+			),
+			Optional: true,
+			Validators: []validator.String{
+				validators.MatchName(),
+				stringvalidator.ConflictsWith(modeConflict),
+			},
+		}
+	}
+	keepIDAttribute := func(kind string) schema.Attribute {
+		return schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("%s the user is mapped to in the user namespace, only supported by the mode `%s`.", kind, podUsernsKeepID),
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "User namespace of the pod, joined by all containers of the pod. " +
+			"Either a `mode` or an explicit mapping is configured.",
+		Optional: true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"mode": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf(
+					"Mode of the user namespace, one of `%s`.",
+					strings.Join(podUsernsModes, "`, `"),
+				),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(podUsernsModes...),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Size of the automatically created user namespace, only supported by the mode `%s`.", podUsernsAuto),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"uid":        keepIDAttribute("UID"),
+			"gid":        keepIDAttribute("GID"),
+			"uid_map":    idMap("UID"),
+			"gid_map":    idMap("GID"),
+			"subuidname": subName("UID", "/etc/subuid"),
+			"subgidname": subName("GID", "/etc/subgid"),
+		},
+	}
+}
+
+// validatePodUserNamespace ensures the options are supported by the user namespace mode
+func validatePodUserNamespace(ctx context.Context, userns types.Object, diags *diag.Diagnostics) {
+	if userns.IsNull() || userns.IsUnknown() {
+		return
+	}
+	var d podUserNamespaceData
+	diags.Append(userns.As(ctx, &d, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || d.Mode.IsUnknown() {
+		return
+	}
+
+	if d.Mode.IsNull() && d.UIDMap.IsNull() && d.GIDMap.IsNull() && d.SubUIDName.IsNull() && d.SubGIDName.IsNull() {
+		diags.AddAttributeError(
+			path.Root("userns"),
+			"Missing user namespace mode",
+			"Either a mode or an explicit mapping (uid_map, gid_map, subuidname, subgidname) must be configured.",
+		)
+		return
+	}
+
+	mode := d.Mode.ValueString()
+	for _, option := range []struct {
+		name  string
+		value types.Int64
+		mode  string
+	}{
+		{"size", d.Size, podUsernsAuto},
+		{"uid", d.UID, podUsernsKeepID},
+		{"gid", d.GID, podUsernsKeepID},
+	} {
+		if !option.value.IsNull() && mode != option.mode {
+			diags.AddAttributeError(
+				path.Root("userns").AtName(option.name),
+				"Unsupported user namespace option",
+				fmt.Sprintf("The option %s is only supported by the user namespace mode %q.", option.name, option.mode),
+			)
+		}
+	}
+}
+
+// usernsMode returns the podman representation of the user namespace mode including its options
+func (d podUserNamespaceData) usernsMode() string {
+	mode := d.Mode.ValueString()
+	var options []string
+	switch mode {
+	case podUsernsAuto:
+		if !d.Size.IsNull() {
+			options = append(options, fmt.Sprintf("size=%d", d.Size.ValueInt64()))
+		}
+	case podUsernsKeepID:
+		if !d.UID.IsNull() {
+			options = append(options, fmt.Sprintf("uid=%d", d.UID.ValueInt64()))
+		}
+		if !d.GID.IsNull() {
+			options = append(options, fmt.Sprintf("gid=%d", d.GID.ValueInt64()))
+		}
+	}
+	if len(options) > 0 {
+		return mode + ":" + strings.Join(options, ",")
+	}
+	return mode
+}
+
+// toPodmanPodUserNamespace adds the user namespace and its id mappings to the pod spec
+func toPodmanPodUserNamespace(ctx context.Context, d *podUserNamespaceData, sp *specgen.PodSpecGenerator, diags *diag.Diagnostics) {
+	if d == nil {
+		return
+	}
+
+	var uidMap, gidMap []string
+	if !d.UIDMap.IsNull() {
+		diags.Append(d.UIDMap.ElementsAs(ctx, &uidMap, false)...)
+	}
+	if !d.GIDMap.IsNull() {
+		diags.Append(d.GIDMap.ElementsAs(ctx, &gidMap, false)...)
+	}
+
+	mode := d.usernsMode()
+	userns, err := specgen.ParseUserNamespace(mode)
+	if err != nil {
+		diags.AddAttributeError(path.Root("userns"), "Invalid user namespace", fmt.Sprintf("Cannot parse user namespace mode: %s", err.Error()))
+		return
+	}
+	if mode == "" {
+		// explicit mappings create a private user namespace
+		userns = specgen.Namespace{NSMode: specgen.Private}
+	}
+
+	mappings, err := util.ParseIDMapping(
+		namespaces.UsernsMode(mode),
+		uidMap,
+		gidMap,
+		d.SubUIDName.ValueString(),
+		d.SubGIDName.ValueString(),
+	)
+	if err != nil {
+		diags.AddAttributeError(path.Root("userns"), "Invalid user namespace", fmt.Sprintf("Cannot build id mappings: %s", err.Error()))
+		return
+	}
+
+	sp.Userns = userns
+	sp.IDMappings = mappings
+}
+
+// fromPodmanPodUserNamespace converts the user namespace of the infra container.
+// podman only reports if the namespace is private, the mode and options are kept from the prior data.
+func fromPodmanPodUserNamespace(hostConfig *define.InspectContainerHostConfig, prior *podUserNamespaceData) *podUserNamespaceData {
+	switch mode, _, _ := strings.Cut(hostConfig.UsernsMode, ":"); mode {
+	case "":
+		// the host namespace is the default and cannot be distinguished from an unset user namespace
+		if prior == nil || prior.Mode.ValueString() == podUsernsHost {
+			return prior
+		}
+		return newPodUserNamespaceData(types.StringValue(podUsernsHost), nil)
+	case "private":
+		if prior == nil && hostConfig.IDMappings == nil {
+			return nil
+		}
+		if prior == nil || prior.Mode.ValueString() == podUsernsHost {
+			// a private namespace without a known mode is described by its mappings
+			return newPodUserNamespaceData(types.StringNull(), hostConfig.IDMappings)
+		}
+		if !prior.Mode.IsNull() || !prior.SubUIDName.IsNull() || !prior.SubGIDName.IsNull() || hostConfig.IDMappings == nil {
+			return prior
+		}
+		state := *prior
+		if !prior.UIDMap.IsNull() {
+			state.UIDMap = fromPodmanIDMap(hostConfig.IDMappings.UIDMap)
+		}
+		if !prior.GIDMap.IsNull() {
+			state.GIDMap = fromPodmanIDMap(hostConfig.IDMappings.GIDMap)
+		}
+		return &state
+	default:
+		// namespaces of other containers or paths are not managed by the pod
+		return prior
+	}
+}
+
+// newPodUserNamespaceData returns the user namespace data of a mode or explicit mappings
+func newPodUserNamespaceData(mode types.String, mappings *define.InspectIDMappings) *podUserNamespaceData {
+	d := &podUserNamespaceData{
+		Mode:       mode,
+		Size:       types.Int64Null(),
+		UID:        types.Int64Null(),
+		GID:        types.Int64Null(),
+		UIDMap:     types.ListNull(types.StringType),
+		GIDMap:     types.ListNull(types.StringType),
+		SubUIDName: types.StringNull(),
+		SubGIDName: types.StringNull(),
+	}
+	if mappings != nil {
+		d.UIDMap = fromPodmanIDMap(mappings.UIDMap)
+		d.GIDMap = fromPodmanIDMap(mappings.GIDMap)
+	}
+	return d
+}
+
+// fromPodmanIDMap converts the id mappings reported as container_id:host_id:size
+func fromPodmanIDMap(mappings []string) types.List {
+	if len(mappings) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	values := make([]attr.Value, 0, len(mappings))
+	for _, m := range mappings {
+		values = append(values, types.StringValue(m))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFromPodmanPodUserNamespace(t *testing.T) {
+	mappings := &define.InspectIDMappings{
+		UIDMap: []string{"0:100000:65536"},
+		GIDMap: []string{"0:100000:65536"},
+	}
+	withMode := func(mode string) *podUserNamespaceData {
+		return newPodUserNamespaceData(types.StringValue(mode), nil)
+	}
+	withUIDMap := func(uidMap ...string) *podUserNamespaceData {
+		d := newPodUserNamespaceData(types.StringNull(), nil)
+		d.UIDMap = fromPodmanIDMap(uidMap)
+		return d
+	}
+
+	tests := []struct {
+		desc       string
+		hostConfig *define.InspectContainerHostConfig
+		prior      *podUserNamespaceData
+		want       *podUserNamespaceData
+	}{
+		{
+			desc:       "Default namespace without prior",
+			hostConfig: &define.InspectContainerHostConfig{},
+			want:       nil,
+		},
+		{
+			desc:       "Host mode is kept",
+			hostConfig: &define.InspectContainerHostConfig{},
+			prior:      withMode(podUsernsHost),
+			want:       withMode(podUsernsHost),
+		},
+		{
+			desc:       "Private mode is kept",
+			hostConfig: &define.InspectContainerHostConfig{UsernsMode: "private", IDMappings: mappings},
+			prior:      withMode(podUsernsAuto),
+			want:       withMode(podUsernsAuto),
+		},
+		{
+			desc:       "Host namespace of a private mode is read",
+			hostConfig: &define.InspectContainerHostConfig{},
+			prior:      withMode(podUsernsAuto),
+			want:       withMode(podUsernsHost),
+		},
+		{
+			desc:       "Private namespace is imported as mappings",
+			hostConfig: &define.InspectContainerHostConfig{UsernsMode: "private", IDMappings: mappings},
+			want:       newPodUserNamespaceData(types.StringNull(), mappings),
+		},
+		{
+			desc:       "Configured mappings are read",
+			hostConfig: &define.InspectContainerHostConfig{UsernsMode: "private", IDMappings: mappings},
+			prior:      withUIDMap("0:200000:65536"),
+			want:       withUIDMap("0:100000:65536"),
+		},
+		{
+			desc:       "Namespaces of other containers are not managed",
+			hostConfig: &define.InspectContainerHostConfig{UsernsMode: "container:abc"},
+			want:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := fromPodmanPodUserNamespace(test.hostConfig, test.prior)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+			}
+		})
+	}
+}
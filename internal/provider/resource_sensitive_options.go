@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/project0/terraform-provider-podman/internal/utils"
+)
+
+const (
+	sensitiveOptionsAttribute     = "sensitive_options"
+	sensitiveOptionsHashAttribute = "sensitive_options_hash"
+)
+
+// withSensitiveOptionsAttributes adds the sensitive driver options and their hash to the attributes
+func withSensitiveOptionsAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes[sensitiveOptionsAttribute] = schema.MapAttribute{
+		MarkdownDescription: "Driver specific options containing secrets, e.g. credentials of a mount. " +
+			"The options are merged into the driver options, but never shown in the plan and not part of `options`. " +
+			"Changes of the values on the podman service are detected by `" + sensitiveOptionsHashAttribute + "`.",
+		Optional:    true,
+		Sensitive:   true,
+		ElementType: types.StringType,
+		Validators: []validator.Map{
+			mapvalidator.SizeAtLeast(1),
+		},
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplace(),
+		},
+	}
+
+	attributes[sensitiveOptionsHashAttribute] = schema.StringAttribute{
+		MarkdownDescription: "HMAC-SHA256 of the sensitive options reported by podman keyed with the resource name, a change forces a replacement.",
+		// replacement is planned by the resource
+		Computed:  true,
+		Sensitive: true,
+	}
+
+	return attributes
+}
+
+// validateSensitiveOptions ensures a sensitive option is neither configured in options nor managed by a typed attribute
+func validateSensitiveOptions(options types.Map, sensitive types.Map, reserved []string, diags *diag.Diagnostics) {
+	if sensitive.IsNull() || sensitive.IsUnknown() {
+		return
+	}
+
+	for key := range sensitive.Elements() {
+		keyPath := path.Root(sensitiveOptionsAttribute).AtMapKey(key)
+		if _, ok := options.Elements()[key]; ok {
+			diags.AddAttributeError(
+				keyPath,
+				"Conflicting driver option",
+				fmt.Sprintf("The option %q is configured in options and sensitive_options.", key),
+			)
+		}
+		if utils.StringInSlice(key, reserved) {
+			diags.AddAttributeError(
+				keyPath,
+				"Conflicting driver option",
+				fmt.Sprintf("The option %q is set by a typed attribute and cannot be sensitive.", key),
+			)
+		}
+	}
+}
+
+// mergeSensitiveOptions adds the sensitive options to the driver options
+func mergeSensitiveOptions(ctx context.Context, sensitive types.Map, options map[string]string, diags *diag.Diagnostics) map[string]string {
+	if sensitive.IsNull() {
+		return options
+	}
+
+	var values map[string]string
+	diags.Append(sensitive.ElementsAs(ctx, &values, false)...)
+	if options == nil {
+		options = make(map[string]string, len(values))
+	}
+	for key, val := range values {
+		options[key] = val
+	}
+	return options
+}
+
+// splitSensitiveOptions removes the configured sensitive options from the reported driver options
+// and returns the hash of their reported values
+func splitSensitiveOptions(name string, options map[string]string, sensitive types.Map) (map[string]string, types.String) {
+	if sensitive.IsNull() || sensitive.IsUnknown() {
+		return options, types.StringNull()
+	}
+
+	result := make(map[string]string, len(options))
+	reported := make(map[string]string, len(sensitive.Elements()))
+	for key, val := range options {
+		if _, ok := sensitive.Elements()[key]; ok {
+			reported[key] = val
+			continue
+		}
+		result[key] = val
+	}
+	return result, types.StringValue(hashSensitiveOptions(name, reported))
+}
+
+// hashSensitiveOptions returns a stable hash of the options,
+// keyed with the resource name to not expose the same digest for equal secrets of different resources
+func hashSensitiveOptions(name string, options map[string]string) string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		// the length prefix keeps keys and values containing the separators distinct
+		fmt.Fprintf(&b, "%d:%s=%d:%s\n", len(key), key, len(options[key]), options[key])
+	}
+	mac := hmac.New(sha256.New, []byte(name))
+	mac.Write([]byte(b.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// modifyPlanSensitiveOptions plans the hash of the configured sensitive options
+// and a replacement if it differs from the hash of the reported options.
+// The optional normalize function rewrites the configured options the way podman reports them.
+func modifyPlanSensitiveOptions(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, normalize func(map[string]string)) {
+	var sensitive types.Map
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(sensitiveOptionsAttribute), &sensitive)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash := types.StringNull()
+	switch {
+	case sensitive.IsNull():
+	case sensitive.IsUnknown() || !sensitiveOptionsKnown(sensitive) || name.IsUnknown():
+		hash = types.StringUnknown()
+	default:
+		var values map[string]string
+		resp.Diagnostics.Append(sensitive.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if normalize != nil {
+			normalize(values)
+		}
+		hash = types.StringValue(hashSensitiveOptions(name.ValueString(), values))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(sensitiveOptionsHashAttribute), hash)...)
+
+	if !req.State.Raw.IsNull() {
+		var stateHash types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(sensitiveOptionsHashAttribute), &stateHash)...)
+		if !hash.Equal(stateHash) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root(sensitiveOptionsHashAttribute))
+		}
+	}
+}
+
+// sensitiveOptionsKnown checks if all values of the sensitive options are known
+func sensitiveOptionsKnown(sensitive types.Map) bool {
+	for _, val := range sensitive.Elements() {
+		if val.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
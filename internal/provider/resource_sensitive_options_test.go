@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/project0/terraform-provider-podman/internal/utils"
+)
+
+func TestHashSensitiveOptions(t *testing.T) {
+	tests := []struct {
+		desc      string
+		nameA     string
+		optionsA  map[string]string
+		nameB     string
+		optionsB  map[string]string
+		wantEqual bool
+	}{
+		{
+			desc:      "Same name and options",
+			nameA:     "data",
+			optionsA:  map[string]string{"password": "secret", "user": "admin"},
+			nameB:     "data",
+			optionsB:  map[string]string{"user": "admin", "password": "secret"},
+			wantEqual: true,
+		},
+		{
+			desc:     "Same options of different resources",
+			nameA:    "data",
+			optionsA: map[string]string{"password": "secret"},
+			nameB:    "backup",
+			optionsB: map[string]string{"password": "secret"},
+		},
+		{
+			desc:     "Different values",
+			nameA:    "data",
+			optionsA: map[string]string{"password": "secret"},
+			nameB:    "data",
+			optionsB: map[string]string{"password": "other"},
+		},
+		{
+			desc:     "Separators in keys and values",
+			nameA:    "data",
+			optionsA: map[string]string{"a": "b=c"},
+			nameB:    "data",
+			optionsB: map[string]string{"a=b": "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			hashA := hashSensitiveOptions(test.nameA, test.optionsA)
+			hashB := hashSensitiveOptions(test.nameB, test.optionsB)
+			if (hashA == hashB) != test.wantEqual {
+				t.Errorf("%s: got hashes %s and %s, want equal %t", test.desc, hashA, hashB, test.wantEqual)
+			}
+		})
+	}
+}
+
+func TestHashSensitiveVolumeOptions(t *testing.T) {
+	tests := []struct {
+		desc       string
+		configured map[string]string
+		reported   map[string]string
+	}{
+		{
+			desc:       "Options are reported unchanged",
+			configured: map[string]string{"o": "username=u,password=p"},
+			reported:   map[string]string{"o": "username=u,password=p"},
+		},
+		{
+			desc:       "Timeout is removed from the mount options",
+			configured: map[string]string{"o": "username=u,password=p,timeout=5"},
+			reported:   map[string]string{"o": "username=u,password=p"},
+		},
+		{
+			desc:       "Noquota is reported as derived option",
+			configured: map[string]string{"o": "username=u,noquota,password=p"},
+			reported:   map[string]string{"o": "username=u,password=p", volumeLocalNoQuotaDerived: "true"},
+		},
+		{
+			desc:       "Mount options without podman options",
+			configured: map[string]string{"o": "timeout=5,noquota", "device": "//server/share"},
+			reported:   map[string]string{"device": "//server/share", volumeLocalNoQuotaDerived: "true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var diags diag.Diagnostics
+			sensitive := utils.MapStringToMapType(test.configured, &diags)
+			state := fromVolumeResponse(&entities.VolumeConfigResponse{
+				InspectVolumeData: define.InspectVolumeData{Name: "data", Options: test.reported},
+			}, sensitive, &diags)
+			if diags.HasError() {
+				t.Fatalf("%s: unexpected diagnostics: %v", test.desc, diags)
+			}
+
+			normalizeVolumeOptions(test.configured)
+			if planned := hashSensitiveOptions("data", test.configured); planned != state.SensitiveOptionsHash.ValueString() {
+				t.Errorf("%s: planned hash %s differs from the reported hash %s", test.desc, planned, state.SensitiveOptionsHash.ValueString())
+			}
+		})
+	}
+}
@@ -3,11 +3,15 @@ package provider
 import (
 	"context"
 
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/project0/terraform-provider-podman/internal/modifier"
 	"github.com/project0/terraform-provider-podman/internal/utils"
@@ -18,20 +22,30 @@ type (
 		genericResource
 	}
 	volumeResourceData struct {
-		ID     types.String `tfsdk:"id"`
-		Name   types.String `tfsdk:"name"`
-		Labels types.Map    `tfsdk:"labels"`
+		ID         types.String `tfsdk:"id"`
+		Name       types.String `tfsdk:"name"`
+		Labels     types.Map    `tfsdk:"labels"`
+		Connection types.String `tfsdk:"connection_name"`
 
-		Driver  types.String `tfsdk:"driver"`
-		Options types.Map    `tfsdk:"options"`
+		Force                    types.Bool `tfsdk:"force"`
+		PreventDestroyIfNotEmpty types.Bool `tfsdk:"prevent_destroy_if_not_empty"`
+
+		Driver  types.String     `tfsdk:"driver"`
+		Options types.Map        `tfsdk:"options"`
+		Local   *volumeLocalData `tfsdk:"local"`
+
+		SensitiveOptions     types.Map    `tfsdk:"sensitive_options"`
+		SensitiveOptionsHash types.String `tfsdk:"sensitive_options_hash"`
 	}
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &volumeResource{}
-	_ resource.ResourceWithConfigure   = &volumeResource{}
-	_ resource.ResourceWithImportState = &volumeResource{}
+	_ resource.Resource                   = &volumeResource{}
+	_ resource.ResourceWithConfigure      = &volumeResource{}
+	_ resource.ResourceWithImportState    = &volumeResource{}
+	_ resource.ResourceWithModifyPlan     = &volumeResource{}
+	_ resource.ResourceWithValidateConfig = &volumeResource{}
 )
 
 // NewVolumeResource creates a new volume resource.
@@ -53,40 +67,129 @@ func (r volumeResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manage volumes for containers and pods",
-		Attributes: withGenericAttributes(
+		Attributes: withGenericAttributes(withSensitiveOptionsAttributes(
 			map[string]schema.Attribute{
 				"driver": schema.StringAttribute{
-					MarkdownDescription: "Name of the volume driver. Defaults by podman to `local`.",
-					Required:            false,
-					Optional:            true,
-					Computed:            true,
+					MarkdownDescription: "Name of the volume driver. Podman provides `local` and `image`, other drivers are volume plugins. " +
+						"The driver must be available on the podman service. Defaults by podman to `local`.",
+					Required: false,
+					Optional: true,
+					Computed: true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
 					PlanModifiers: []planmodifier.String{
 						modifier.RequiresReplaceComputed(),
 					},
 				},
 				"options": schema.MapAttribute{
-					Description: "Driver specific options.",
+					Description: "Driver specific options. The typed options of `local` are merged into this map.",
 					Required:    false,
 					Optional:    true,
 					Computed:    true,
 					ElementType: types.StringType,
+					// replacement is planned by the resource, typed options are merged into the map
 					PlanModifiers: []planmodifier.Map{
 						modifier.UseDefaultModifier(utils.MapStringEmpty()),
-						modifier.RequiresReplaceComputed(),
 					},
 				},
+				"local": volumeLocalSchema(),
+				"force": schema.BoolAttribute{
+					MarkdownDescription: "Remove the containers using the volume before it is destroyed, " +
+						"pods whose infra container uses the volume are removed as well. " +
+						"Otherwise destroying a volume which is still in use fails. Defaults to `false`.",
+					Optional: true,
+				},
+				"prevent_destroy_if_not_empty": schema.BoolAttribute{
+					MarkdownDescription: "Fail to destroy the volume if it contains data, also on a replacement. " +
+						"The size is reported by podman for mounted volumes only, destroying an unmounted plugin volume fails as well. " +
+						"Only the size of the files is counted, a volume containing only directories or empty files is considered empty. " +
+						"Defaults to `false`.",
+					Optional: true,
+				},
 			},
-		),
+		)),
+	}
+}
+
+// connectionName returns the name of the selected provider connection
+func (d volumeResourceData) connectionName() string {
+	return d.Connection.ValueString()
+}
+
+// ValidateConfig validates the volume configuration.
+func (r volumeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var driver types.String
+	var options, sensitive types.Map
+	var local types.Object
+	for _, attribute := range []struct {
+		name   string
+		target interface{}
+	}{
+		{"driver", &driver},
+		{"options", &options},
+		{"local", &local},
+		{sensitiveOptionsAttribute, &sensitive},
+	} {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute.name), attribute.target)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	validateVolumeLocal(ctx, driver, local, options, &resp.Diagnostics)
+
+	// options of the local attribute cannot be sensitive
+	var reserved []string
+	if !local.IsNull() {
+		reserved = volumeLocalOptions
+	}
+	validateSensitiveOptions(options, sensitive, reserved, &resp.Diagnostics)
+}
+
+// ModifyPlan ensures the configured driver is available and plans the driver options.
+func (r volumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	modifyPlanVolumeOptions(ctx, req, resp)
+	modifyPlanSensitiveOptions(ctx, req, resp, normalizeVolumeOptions)
+
+	r.modifyPlanDriverPlugin(ctx, req, resp, func(p define.Plugins) []string {
+		// the image driver is managed by podman and not reported as plugin
+		return append(p.Volume, define.VolumeDriverImage)
+	})
 }
 
-func fromVolumeResponse(v *entities.VolumeConfigResponse, diags *diag.Diagnostics) *volumeResourceData {
+func fromVolumeResponse(v *entities.VolumeConfigResponse, sensitive types.Map, diags *diag.Diagnostics) *volumeResourceData {
+	// podman adds the parsed mount options as separate options, they are part of the configured options
+	options := make(map[string]string, len(v.Options))
+	for key, val := range v.Options {
+		if !utils.StringInSlice(key, volumeDerivedOptions) {
+			options[key] = val
+		}
+	}
+	// noquota is removed from the mount options and only reported as derived option
+	if v.Options[volumeLocalNoQuotaDerived] == "true" {
+		if o, ok := options[volumeLocalMountOption]; ok {
+			options[volumeLocalMountOption] = o + "," + volumeLocalNoQuota
+		} else {
+			options[volumeLocalMountOption] = volumeLocalNoQuota
+		}
+	}
+	options, sensitiveHash := splitSensitiveOptions(v.Name, options, sensitive)
+
 	return &volumeResourceData{
 		// volumes do not have IDs, it wilbe mapped to the unique name
 		ID:      types.StringValue(v.Name),
 		Name:    types.StringValue(v.Name),
 		Driver:  types.StringValue(v.Driver),
 		Labels:  utils.MapStringToMapType(v.Labels, diags),
-		Options: utils.MapStringToMapType(v.Options, diags),
+		Options: utils.MapStringToMapType(options, diags),
+
+		SensitiveOptions:     sensitive,
+		SensitiveOptionsHash: sensitiveHash,
 	}
 }
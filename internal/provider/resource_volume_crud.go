@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/containers/podman/v4/pkg/bindings/containers"
+	"github.com/containers/podman/v4/pkg/bindings/pods"
+	"github.com/containers/podman/v4/pkg/bindings/system"
 	"github.com/containers/podman/v4/pkg/bindings/volumes"
 	"github.com/containers/podman/v4/pkg/domain/entities"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/project0/terraform-provider-podman/internal/utils"
 )
 
 func (r volumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -36,6 +39,15 @@ func (r volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	if !data.Options.IsNull() {
 		resp.Diagnostics.Append(data.Options.ElementsAs(ctx, &volCreate.Options, true)...)
 	}
+	if data.Local != nil {
+		if volCreate.Options == nil {
+			volCreate.Options = make(map[string]string)
+		}
+		for key, val := range toPodmanVolumeLocalOptions(ctx, data.Local, &resp.Diagnostics) {
+			volCreate.Options[key] = val
+		}
+	}
+	volCreate.Options = mergeSensitiveOptions(ctx, data.SensitiveOptions, volCreate.Options, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -49,8 +61,16 @@ func (r volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Set state
+	state := fromVolumeResponse(volResponse, data.SensitiveOptions, &resp.Diagnostics)
+	state.Connection = data.Connection
+	state.Force = data.Force
+	state.PreventDestroyIfNotEmpty = data.PreventDestroyIfNotEmpty
+	if data.Local != nil {
+		state.Local = fromPodmanVolumeLocalOptions(volResponse.Options, data.Local, &resp.Diagnostics)
+	}
+
 	resp.Diagnostics.Append(
-		resp.State.Set(ctx, fromVolumeResponse(volResponse, &resp.Diagnostics))...,
+		resp.State.Set(ctx, state)...,
 	)
 }
 
@@ -77,17 +97,34 @@ func (r volumeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Set state
+	state := fromVolumeResponse(volResponse, data.SensitiveOptions, &resp.Diagnostics)
+	state.Connection = data.Connection
+	state.Force = data.Force
+	state.PreventDestroyIfNotEmpty = data.PreventDestroyIfNotEmpty
+	if data.Local != nil {
+		state.Local = fromPodmanVolumeLocalOptions(volResponse.Options, data.Local, &resp.Diagnostics)
+	}
+
 	resp.Diagnostics.Append(
-		resp.State.Set(ctx, fromVolumeResponse(volResponse, &resp.Diagnostics))...,
+		resp.State.Set(ctx, state)...,
 	)
 }
 
-// Update is not implemented
+// Update applies the destroy options in place, volumes are immutable and all other attributes require a replacement.
 func (r volumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	utils.AddUnexpectedError(
-		&resp.Diagnostics,
-		"Update triggered for a volume resource",
-		"Volumes are immutable resources and cannot be updated, it always needs to be replaced.",
+	var data, state volumeResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Force = data.Force
+	state.PreventDestroyIfNotEmpty = data.PreventDestroyIfNotEmpty
+
+	resp.Diagnostics.Append(
+		resp.State.Set(ctx, state)...,
 	)
 }
 
@@ -99,15 +136,107 @@ func (r volumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// TODO: Allow force ?
-	err := volumes.Remove(client, data.ID.ValueString(), nil)
+	if data.PreventDestroyIfNotEmpty.ValueBool() {
+		checkVolumeEmpty(client, data.ID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.Force.ValueBool() {
+		removeVolumePods(client, data.ID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// force removes the containers using the volume
+	rmOpts := new(volumes.RemoveOptions).WithForce(data.Force.ValueBool())
+	err := volumes.Remove(client, data.ID.ValueString(), rmOpts)
 	if err != nil {
 		resp.Diagnostics.AddError("Podman client error", fmt.Sprintf("Failed to delete volume resource: %s", err.Error()))
+		addVolumeContainersDiagnostics(client, data.ID.ValueString(), &resp.Diagnostics)
+		return
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
+// checkVolumeEmpty ensures the volume does not contain any data
+func checkVolumeEmpty(client context.Context, name string, diags *diag.Diagnostics) {
+	report, err := system.DiskUsage(client, nil)
+	if err != nil {
+		diags.AddError("Podman client error", fmt.Sprintf("Failed to retrieve the size of the volume resource: %s", err.Error()))
+		return
+	}
+
+	for _, v := range report.Volumes {
+		if v.VolumeName != name {
+			continue
+		}
+		// the size is the sum of the file sizes, directories and empty files are not counted
+		if v.Size > 0 {
+			diags.AddError(
+				"Volume is not empty",
+				fmt.Sprintf("The volume %s contains %d bytes of data. Remove the data or unset prevent_destroy_if_not_empty to destroy it.", name, v.Size),
+			)
+		}
+		return
+	}
+
+	// podman reports the size of mounted volumes only
+	diags.AddError(
+		"Unknown volume size",
+		fmt.Sprintf("The size of the volume %s is not reported by podman, it may not be mounted. Unset prevent_destroy_if_not_empty to destroy it.", name),
+	)
+}
+
+// volumeContainers lists all containers using the volume
+func volumeContainers(client context.Context, name string) ([]entities.ListContainer, error) {
+	listOpts := new(containers.ListOptions).
+		WithAll(true).
+		WithFilters(map[string][]string{"volume": {name}})
+
+	return containers.List(client, listOpts)
+}
+
+// removeVolumePods removes the pods whose infra container uses the volume,
+// podman refuses to remove an infra container without its pod
+func removeVolumePods(client context.Context, name string, diags *diag.Diagnostics) {
+	list, err := volumeContainers(client, name)
+	if err != nil {
+		diags.AddError("Podman client error", fmt.Sprintf("Failed to list containers of volume resource: %s", err.Error()))
+		return
+	}
+
+	for _, c := range list {
+		if !c.IsInfra {
+			continue
+		}
+		if _, err := pods.Remove(client, c.Pod, new(pods.RemoveOptions).WithForce(true)); err != nil {
+			diags.AddError("Podman client error", fmt.Sprintf("Failed to remove pod %s using the volume resource: %s", c.PodName, err.Error()))
+		}
+	}
+}
+
+// addVolumeContainersDiagnostics reports the containers which are still using the volume
+func addVolumeContainersDiagnostics(client context.Context, name string, diags *diag.Diagnostics) {
+	list, err := volumeContainers(client, name)
+	if err != nil || len(list) == 0 {
+		// the deletion error is already reported
+		return
+	}
+
+	names := make([]string, 0, len(list))
+	for _, c := range list {
+		names = append(names, containerName(c))
+	}
+	diags.AddError(
+		"Volume is in use",
+		fmt.Sprintf("The volume %s is used by the containers: %s. Remove the containers or set force to remove them together with the volume.", name, strings.Join(names, ", ")),
+	)
+}
+
 func (r volumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithConnection(ctx, req, resp)
 }
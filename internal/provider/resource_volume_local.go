@@ -0,0 +1,433 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/project0/terraform-provider-podman/internal/utils"
+	"github.com/project0/terraform-provider-podman/internal/validators"
+)
+
+const (
+	volumeLocalTypeOption   = "type"
+	volumeLocalDeviceOption = "device"
+	volumeLocalMountOption  = "o"
+	volumeLocalNoCopyOption = "nocopy"
+
+	// volumeLocalNoQuota disables the quota of the volume path, podman removes it from the mount options
+	volumeLocalNoQuota        = "noquota"
+	volumeLocalNoQuotaDerived = "NOQUOTA"
+)
+
+var (
+	// volumeLocalOptions are the driver options managed by the local attribute
+	volumeLocalOptions = []string{volumeLocalTypeOption, volumeLocalDeviceOption, volumeLocalMountOption, volumeLocalNoCopyOption}
+
+	// volumeLocalTypedMountOptions are the mount options with an own attribute
+	volumeLocalTypedMountOptions = []string{"uid", "gid", "size", "inodes", volumeLocalNoQuota}
+
+	// volumeLocalUnsupportedMountOptions are removed from the mount options by podman without being recorded
+	volumeLocalUnsupportedMountOptions = []string{"timeout"}
+
+	// volumeDerivedOptions are added by podman for the parsed mount options
+	volumeDerivedOptions = []string{"UID", "GID", "SIZE", "INODES", volumeLocalNoQuotaDerived}
+
+	// volumeNFSTypes are the filesystem types mounting a remote NFS export
+	volumeNFSTypes = []string{"nfs", "nfs4"}
+
+	regexHostname = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+)
+
+type (
+	// volumeLocalData are the typed options of the local volume driver
+	volumeLocalData struct {
+		Type         types.String `tfsdk:"type"`
+		Device       types.String `tfsdk:"device"`
+		MountOptions types.List   `tfsdk:"mount_options"`
+		UID          types.Int64  `tfsdk:"uid"`
+		GID          types.Int64  `tfsdk:"gid"`
+		Size         types.String `tfsdk:"size"`
+		Inodes       types.Int64  `tfsdk:"inodes"`
+		NoQuota      types.Bool   `tfsdk:"noquota"`
+		NoCopy       types.Bool   `tfsdk:"nocopy"`
+	}
+)
+
+func volumeLocalSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"Typed options of the `%s` driver, converted to the driver options `%s`. Cannot be combined with these keys in `options`.",
+			define.VolumeDriverLocal,
+			strings.Join(volumeLocalOptions, "`, `"),
+		),
+		Optional: true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Filesystem type of the device mounted as volume, e.g. `tmpfs`, `nfs` or `xfs`. Requires `device`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("device")),
+				},
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "Device mounted as volume, e.g. `tmpfs`, `/dev/sdb1` or `server:/export` for NFS. Requires `type`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("type")),
+				},
+			},
+			"mount_options": schema.ListAttribute{
+				MarkdownDescription: "Options used to mount the device, e.g. `noatime` or `addr=10.0.0.1`. " +
+					"Ownership, size, inodes and quota are configured by their attributes, the podman option `timeout` is not supported.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(validators.MatchMountOption()),
+				},
+			},
+			"uid": schema.Int64Attribute{
+				MarkdownDescription: "UID owning the volume.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"gid": schema.Int64Attribute{
+				MarkdownDescription: "GID owning the volume.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"size": schema.StringAttribute{
+				MarkdownDescription: "Maximum size of the volume with an optional unit, e.g. `512m`. " +
+					"Requires project quota support of the volume path (xfs) unless `noquota` is set, sets the size of `tmpfs` devices.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.MatchSize(),
+				},
+			},
+			"inodes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of inodes of the volume. Requires project quota support of the volume path (xfs) unless `noquota` is set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"noquota": schema.BoolAttribute{
+				MarkdownDescription: "Do not set a project quota on the volume path. " +
+					"Required for `size` and `inodes` if the volume path does not support project quota, e.g. to size `tmpfs` devices on ext4.",
+				Optional: true,
+			},
+			"nocopy": schema.BoolAttribute{
+				MarkdownDescription: "Do not copy the content of the image at the mount destination into the empty volume.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// validateVolumeLocal ensures the typed options are supported by the driver and are valid for the device type
+func validateVolumeLocal(ctx context.Context, driver types.String, local types.Object, options types.Map, diags *diag.Diagnostics) {
+	if local.IsNull() || local.IsUnknown() {
+		return
+	}
+	localPath := path.Root("local")
+
+	if !driver.IsNull() && !driver.IsUnknown() && driver.ValueString() != define.VolumeDriverLocal {
+		diags.AddAttributeError(
+			localPath,
+			"Unsupported driver option",
+			fmt.Sprintf("The local options are not supported by the %q driver.", driver.ValueString()),
+		)
+		return
+	}
+
+	if !options.IsUnknown() {
+		for key := range options.Elements() {
+			if utils.StringInSlice(key, volumeLocalOptions) {
+				diags.AddAttributeError(
+					path.Root("options").AtMapKey(key),
+					"Conflicting driver option",
+					fmt.Sprintf("The option %q is set by the local attribute.", key),
+				)
+			}
+		}
+	}
+
+	var d volumeLocalData
+	diags.Append(local.As(ctx, &d, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return
+	}
+
+	var mountOptions []string
+	if !d.MountOptions.IsNull() && !d.MountOptions.IsUnknown() {
+		diags.Append(d.MountOptions.ElementsAs(ctx, &mountOptions, true)...)
+	}
+	for i, o := range mountOptions {
+		key, value, _ := strings.Cut(o, "=")
+		if utils.StringInSlice(key, volumeLocalTypedMountOptions) {
+			diags.AddAttributeError(
+				localPath.AtName("mount_options").AtListIndex(i),
+				"Conflicting mount option",
+				fmt.Sprintf("The mount option %q is configured by the attribute %s.", key, key),
+			)
+		}
+		if utils.StringInSlice(key, volumeLocalUnsupportedMountOptions) {
+			diags.AddAttributeError(
+				localPath.AtName("mount_options").AtListIndex(i),
+				"Unsupported mount option",
+				fmt.Sprintf("The mount option %q is removed from the options by podman and cannot be managed.", key),
+			)
+		}
+		if key == "addr" && net.ParseIP(value) == nil {
+			diags.AddAttributeError(
+				localPath.AtName("mount_options").AtListIndex(i),
+				"Invalid mount option",
+				fmt.Sprintf("The address %q is not an IP address.", value),
+			)
+		}
+	}
+
+	if d.Type.IsUnknown() || d.Device.IsUnknown() || d.Device.IsNull() {
+		return
+	}
+	device := d.Device.ValueString()
+	switch {
+	case utils.StringInSlice(d.Type.ValueString(), volumeNFSTypes):
+		host, _, found := strings.Cut(device, ":/")
+		if !found || !validNFSHost(host) {
+			diags.AddAttributeError(
+				localPath.AtName("device"),
+				"Invalid NFS device",
+				fmt.Sprintf("The device %q must be the NFS server and the absolute path of the export, e.g. server:/export.", device),
+			)
+		}
+	case d.Type.ValueString() == "bind":
+		if !strings.HasPrefix(device, "/") {
+			diags.AddAttributeError(
+				localPath.AtName("device"),
+				"Invalid bind device",
+				fmt.Sprintf("The device %q of a bind mount must be an absolute path.", device),
+			)
+		}
+	}
+}
+
+// validNFSHost checks the server of a NFS device is a hostname or an IP address
+func validNFSHost(host string) bool {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return net.ParseIP(strings.Trim(host, "[]")) != nil
+	}
+	return net.ParseIP(host) != nil || regexHostname.MatchString(host)
+}
+
+// toPodmanVolumeLocalOptions converts the typed options to the driver options of the local driver
+func toPodmanVolumeLocalOptions(ctx context.Context, d *volumeLocalData, diags *diag.Diagnostics) map[string]string {
+	options := make(map[string]string)
+	if d == nil {
+		return options
+	}
+
+	if !d.Type.IsNull() {
+		options[volumeLocalTypeOption] = d.Type.ValueString()
+	}
+	if !d.Device.IsNull() {
+		options[volumeLocalDeviceOption] = d.Device.ValueString()
+	}
+
+	var mountOptions []string
+	if !d.MountOptions.IsNull() {
+		diags.Append(d.MountOptions.ElementsAs(ctx, &mountOptions, false)...)
+	}
+	for _, o := range []struct {
+		key   string
+		value attr.Value
+	}{
+		{"uid", d.UID},
+		{"gid", d.GID},
+		{"size", d.Size},
+		{"inodes", d.Inodes},
+	} {
+		if s, ok := driverOptionString(o.value); ok {
+			mountOptions = append(mountOptions, o.key+"="+s)
+		}
+	}
+	// noquota is appended last, the reported options are restored in the same order
+	if d.NoQuota.ValueBool() {
+		mountOptions = append(mountOptions, volumeLocalNoQuota)
+	}
+	if len(mountOptions) > 0 {
+		options[volumeLocalMountOption] = strings.Join(mountOptions, ",")
+	}
+
+	if d.NoCopy.ValueBool() {
+		options[volumeLocalNoCopyOption] = "true"
+	}
+	return options
+}
+
+// fromPodmanVolumeLocalOptions converts the driver options of the local driver to the typed options.
+// Values without a driver option keep the representation of the prior options.
+func fromPodmanVolumeLocalOptions(options map[string]string, prior *volumeLocalData, diags *diag.Diagnostics) *volumeLocalData {
+	d := &volumeLocalData{
+		Type:         utils.MapStringValueToStringType(options, volumeLocalTypeOption),
+		Device:       utils.MapStringValueToStringType(options, volumeLocalDeviceOption),
+		MountOptions: types.ListNull(types.StringType),
+		UID:          types.Int64Null(),
+		GID:          types.Int64Null(),
+		Size:         types.StringNull(),
+		Inodes:       types.Int64Null(),
+		NoQuota:      types.BoolNull(),
+		NoCopy:       types.BoolNull(),
+	}
+
+	var mountOptions []attr.Value
+	if options[volumeLocalMountOption] != "" {
+		for _, o := range strings.Split(options[volumeLocalMountOption], ",") {
+			key, value, _ := strings.Cut(o, "=")
+			switch key {
+			case "uid":
+				d.UID = volumeLocalInt64(key, value, diags)
+			case "gid":
+				d.GID = volumeLocalInt64(key, value, diags)
+			case "inodes":
+				d.Inodes = volumeLocalInt64(key, value, diags)
+			case "size":
+				d.Size = types.StringValue(value)
+			default:
+				mountOptions = append(mountOptions, types.StringValue(o))
+			}
+		}
+	}
+	if len(mountOptions) > 0 {
+		d.MountOptions = types.ListValueMust(types.StringType, mountOptions)
+	}
+
+	if options[volumeLocalNoQuotaDerived] == "true" {
+		d.NoQuota = types.BoolValue(true)
+	} else if prior != nil && !prior.NoQuota.IsNull() {
+		d.NoQuota = types.BoolValue(false)
+	}
+
+	if _, ok := options[volumeLocalNoCopyOption]; ok {
+		d.NoCopy = types.BoolValue(true)
+	} else if prior != nil && !prior.NoCopy.IsNull() {
+		d.NoCopy = types.BoolValue(false)
+	}
+	return d
+}
+
+// normalizeVolumeOptions rewrites the mount options the way podman reports them,
+// timeout is removed and noquota is restored at the end of the options
+func normalizeVolumeOptions(options map[string]string) {
+	o, ok := options[volumeLocalMountOption]
+	if !ok {
+		return
+	}
+	var mountOptions []string
+	noQuota := false
+	for _, mountOption := range strings.Split(o, ",") {
+		key, _, _ := strings.Cut(mountOption, "=")
+		switch {
+		case strings.EqualFold(key, volumeLocalNoQuota):
+			noQuota = true
+		case utils.StringInSlice(strings.ToLower(key), volumeLocalUnsupportedMountOptions):
+		default:
+			mountOptions = append(mountOptions, mountOption)
+		}
+	}
+	if noQuota {
+		mountOptions = append(mountOptions, volumeLocalNoQuota)
+	}
+	if len(mountOptions) == 0 {
+		delete(options, volumeLocalMountOption)
+		return
+	}
+	options[volumeLocalMountOption] = strings.Join(mountOptions, ",")
+}
+
+func volumeLocalInt64(key string, value string, diags *diag.Diagnostics) types.Int64 {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		diags.AddError("Invalid volume option", fmt.Sprintf("Cannot parse the mount option %s=%s: %s", key, value, err.Error()))
+		return types.Int64Null()
+	}
+	return types.Int64Value(i)
+}
+
+// modifyPlanVolumeOptions merges the typed local options into the planned driver options
+func modifyPlanVolumeOptions(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configOptions, planOptions types.Map
+	var local types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("options"), &configOptions)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("options"), &planOptions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("local"), &local)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !local.IsNull() {
+		var d volumeLocalData
+		if !local.IsUnknown() {
+			resp.Diagnostics.Append(local.As(ctx, &d, basetypes.ObjectAsOptions{})...)
+		}
+		if local.IsUnknown() || configOptions.IsUnknown() || volumeLocalUnknown(d) {
+			planOptions = types.MapUnknown(types.StringType)
+		} else {
+			var options map[string]string
+			resp.Diagnostics.Append(configOptions.ElementsAs(ctx, &options, true)...)
+			if options == nil {
+				options = make(map[string]string)
+			}
+			for key, val := range toPodmanVolumeLocalOptions(ctx, &d, &resp.Diagnostics) {
+				options[key] = val
+			}
+			planOptions = utils.MapStringToMapType(options, &resp.Diagnostics)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("options"), planOptions)...)
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateOptions types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("options"), &stateOptions)...)
+		if !planOptions.Equal(stateOptions) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("options"))
+		}
+	}
+}
+
+// volumeLocalUnknown checks if any of the typed options is unknown
+func volumeLocalUnknown(d volumeLocalData) bool {
+	for _, v := range []attr.Value{d.Type, d.Device, d.MountOptions, d.UID, d.GID, d.Size, d.Inodes, d.NoQuota, d.NoCopy} {
+		if v.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/bindings/containers"
+	"github.com/containers/podman/v4/pkg/bindings/volumes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceVolume_basic(t *testing.T) {
@@ -71,6 +80,235 @@ func TestAccResourceVolume_local(t *testing.T) {
 	})
 }
 
+func TestAccResourceVolume_localOptions(t *testing.T) {
+	name1 := generateResourceName()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVolumeConfigLocal(name1, "nfs", "nfs-server", `["addr=10.0.0.1"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid NFS device"),
+			},
+			{
+				Config:      testAccResourceVolumeConfigLocal(name1, "tmpfs", "tmpfs", `["uid=1000"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting mount option"),
+			},
+			{
+				Config:      testAccResourceVolumeConfigLocal(name1, "tmpfs", "tmpfs", `["noquota"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting mount option"),
+			},
+			{
+				Config:      testAccResourceVolumeConfigLocal(name1, "tmpfs", "tmpfs", `["timeout=10"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported mount option"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourceVolumeConfigLocal(name1, "tmpfs", "tmpfs", `["noexec"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "local.type", "tmpfs"),
+					resource.TestCheckResourceAttr("podman_volume.test", "local.size", "64m"),
+					resource.TestCheckResourceAttr("podman_volume.test", "local.uid", "1000"),
+					resource.TestCheckResourceAttr("podman_volume.test", "local.mount_options.#", "1"),
+					resource.TestCheckResourceAttr("podman_volume.test", "local.mount_options.0", "noexec"),
+					resource.TestCheckResourceAttr("podman_volume.test", "local.noquota", "true"),
+					resource.TestCheckResourceAttr("podman_volume.test", "local.nocopy", "true"),
+					resource.TestCheckResourceAttr("podman_volume.test", "options.o", "noexec,uid=1000,size=64m,noquota"),
+					resource.TestCheckResourceAttr("podman_volume.test", "options.nocopy", "true"),
+				),
+			},
+			// the retrieved options do not differ from the typed options
+			{
+				Config:   testAccResourceVolumeConfigLocal(name1, "tmpfs", "tmpfs", `["noexec"]`),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceVolume_sensitiveOptions(t *testing.T) {
+	name1 := generateResourceName()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVolumeConfigSensitive(name1, "type", "tmpfs", "noexec"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting driver option"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccResourceVolumeConfigSensitive(name1, "o", "tmpfs", "noexec"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "options.%", "2"),
+					resource.TestCheckNoResourceAttr("podman_volume.test", "options.o"),
+					resource.TestCheckResourceAttr("podman_volume.test", "sensitive_options.o", "noexec"),
+					resource.TestCheckResourceAttrSet("podman_volume.test", "sensitive_options_hash"),
+				),
+			},
+			// the reported sensitive options match the configured options
+			{
+				Config:   testAccResourceVolumeConfigSensitive(name1, "o", "tmpfs", "noexec"),
+				PlanOnly: true,
+			},
+			// changed sensitive options force a replacement
+			{
+				Config: testAccResourceVolumeConfigSensitive(name1, "o", "tmpfs", "nodev"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "sensitive_options.o", "nodev"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceVolume_destroyOptions(t *testing.T) {
+	name1 := generateResourceName()
+	pod := fmt.Sprintf(`
+resource "podman_pod" "test" {
+	name = %[1]q
+	mounts = [
+		{
+			destination = "/data"
+			volume = {
+				name = %[1]q
+			}
+		},
+	]
+	%[2]s
+}
+`, name1, "depends_on = [podman_volume.test]")
+	podWithoutVolume := strings.Replace(pod, "depends_on = [podman_volume.test]", "", 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceVolumeConfigDestroy(name1, false, true) + pod,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "force", "false"),
+					resource.TestCheckResourceAttr("podman_volume.test", "prevent_destroy_if_not_empty", "true"),
+				),
+			},
+			// Update in place
+			{
+				Config: testAccResourceVolumeConfigDestroy(name1, false, false) + pod,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "prevent_destroy_if_not_empty", "false"),
+				),
+			},
+			// the infra container of the pod uses the volume
+			{
+				Config:      podWithoutVolume,
+				ExpectError: regexp.MustCompile("Volume is in use"),
+			},
+			// Update force in place
+			{
+				Config: testAccResourceVolumeConfigDestroy(name1, true, true) + pod,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "force", "true"),
+				),
+			},
+			// the empty volume is destroyed together with the pod using it
+			{
+				Config: podWithoutVolume,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVolumeRemovedWithPod(name1, "podman_pod.test"),
+				),
+				// the removed pod is recreated
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceVolume_preventDestroyIfNotEmpty(t *testing.T) {
+	name1 := generateResourceName()
+	name2 := generateResourceName()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the data is written to the volume path on the podman host
+			if !strings.HasPrefix(testAccProviderURI(), "unix://") {
+				t.Skip("Writing volume data requires a local podman server")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceVolumeConfigDestroy(name1, false, true),
+			},
+			// the replacement cannot destroy the volume containing data
+			{
+				PreConfig:   func() { testAccVolumeWriteData(t, name1) },
+				Config:      testAccResourceVolumeConfigDestroy(name2, false, true),
+				ExpectError: regexp.MustCompile("Volume is not empty"),
+			},
+			// the volume is kept and can be destroyed without the option
+			{
+				Config: testAccResourceVolumeConfigDestroy(name1, false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "name", name1),
+					resource.TestCheckResourceAttr("podman_volume.test", "prevent_destroy_if_not_empty", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccResourceVolume_unavailableDriver(t *testing.T) {
+	name1 := generateResourceName()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVolumeConfigFull(name1, "tfacc-missing", "o", "noexec"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported driver"),
+			},
+		},
+	})
+}
+
+func TestAccResourceVolume_connection(t *testing.T) {
+	name1 := generateResourceName()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccResourceVolumeConfigConnection(name1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("podman_volume.test", "name", name1),
+					resource.TestCheckResourceAttr("podman_volume.test", "connection_name", "testacc"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "podman_volume.test",
+				ImportState:       true,
+				ImportStateId:     "testacc/" + name1,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func testAccResourceVolumeConfig(name string) string {
 	return fmt.Sprintf(`
 resource "podman_volume" "test" {
@@ -91,3 +329,111 @@ resource "podman_volume" "test" {
 }
 `, driver, optkey, optvalue)
 }
+
+func testAccResourceVolumeConfigLocal(name, fsType, device, mountOptions string) string {
+	return fmt.Sprintf(`
+resource "podman_volume" "test" {
+	name = %[1]q
+
+	local = {
+		type          = %[2]q
+		device        = %[3]q
+		mount_options = %[4]s
+		size          = "64m"
+		uid           = 1000
+		noquota       = true
+		nocopy        = true
+	}
+}
+`, name, fsType, device, mountOptions)
+}
+
+func testAccResourceVolumeConfigSensitive(name, key, device, value string) string {
+	return fmt.Sprintf(`
+resource "podman_volume" "test" {
+	name = %[1]q
+
+	options = {
+		type   = "tmpfs"
+		device = %[3]q
+	}
+	sensitive_options = {
+		%[2]s = %[4]q
+	}
+}
+`, name, key, device, value)
+}
+
+func testAccResourceVolumeConfigDestroy(name string, force, preventNotEmpty bool) string {
+	return fmt.Sprintf(`
+resource "podman_volume" "test" {
+	name = %[1]q
+
+	force                        = %[2]t
+	prevent_destroy_if_not_empty = %[3]t
+}
+`, name, force, preventNotEmpty)
+}
+
+// testAccVolumeWriteData writes a file into the mount point of the volume
+func testAccVolumeWriteData(t *testing.T, name string) {
+	conn, err := bindings.NewConnection(context.Background(), testAccProviderURI())
+	if err != nil {
+		t.Fatalf("Failed to connect to podman server: %s", err.Error())
+	}
+	v, err := volumes.Inspect(conn, name, nil)
+	if err != nil {
+		t.Fatalf("Failed to inspect volume %s: %s", name, err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(v.Mountpoint, "data"), []byte("data"), 0o644); err != nil {
+		t.Fatalf("Failed to write data into volume %s: %s", name, err.Error())
+	}
+}
+
+// testAccCheckVolumeRemovedWithPod ensures the volume and the infra container of the pod do not exist anymore
+func testAccCheckVolumeRemovedWithPod(name, pod string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[pod]
+		if !ok {
+			return fmt.Errorf("Resource %s not found in state", pod)
+		}
+
+		conn, err := bindings.NewConnection(context.Background(), testAccProviderURI())
+		if err != nil {
+			return fmt.Errorf("Failed to connect to podman server: %w", err)
+		}
+		exists, err := volumes.Exists(conn, name, nil)
+		if err != nil {
+			return fmt.Errorf("Failed to check volume %s: %w", name, err)
+		}
+		if exists {
+			return fmt.Errorf("Volume %s still exists", name)
+		}
+		infraID := rs.Primary.Attributes["infra_container_id"]
+		exists, err = containers.Exists(conn, infraID, nil)
+		if err != nil {
+			return fmt.Errorf("Failed to check infra container %s: %w", infraID, err)
+		}
+		if exists {
+			return fmt.Errorf("Infra container %s still exists", infraID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVolumeConfigConnection(name string) string {
+	return fmt.Sprintf(`
+provider "podman" {
+	connections = {
+		testacc = {
+			uri = %[2]q
+		}
+	}
+}
+
+resource "podman_volume" "test" {
+	name            = %[1]q
+	connection_name = "testacc"
+}
+`, name, testAccProviderURI())
+}
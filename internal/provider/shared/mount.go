@@ -2,10 +2,14 @@ package shared
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,10 +29,13 @@ type (
 
 	Mount struct {
 		Destination types.String `tfsdk:"destination"`
+		Options     types.List   `tfsdk:"options"`
 
-		Volume *MountVolume `tfsdk:"volume"`
-		Bind   *MountBind   `tfsdk:"bind"`
-		// Tmpfs  *MountTmpfs   `tfsdk:"tmpfs"`
+		Volume  *MountVolume  `tfsdk:"volume"`
+		Bind    *MountBind    `tfsdk:"bind"`
+		Tmpfs   *MountTmpfs   `tfsdk:"tmpfs"`
+		Image   *MountImage   `tfsdk:"image"`
+		Overlay *MountOverlay `tfsdk:"overlay"`
 	}
 
 	// MountVolume mounts a named volume
@@ -74,6 +81,27 @@ type (
 		Mode      types.String `tfsdk:"mode"`
 		TmpCopyUp types.Bool   `tfsdk:"tmpcopyup"`
 	}
+
+	// MountImage mounts the content of an image
+	MountImage struct {
+		Name types.String `tfsdk:"name"`
+
+		ReadOnly types.Bool `tfsdk:"read_only"`
+	}
+
+	// MountOverlay mounts a host path with an overlay, writes do not modify the host path
+	MountOverlay struct {
+		Path types.String `tfsdk:"path"`
+
+		Chown    types.Bool   `tfsdk:"chown"`
+		UpperDir types.String `tfsdk:"upper_dir"`
+		WorkDir  types.String `tfsdk:"work_dir"`
+	}
+)
+
+var (
+	// mountTypes are the attributes of the mount types, only one can be set per mount
+	mountTypes = []string{"volume", "bind", "tmpfs", "image", "overlay"}
 )
 
 func (m Mounts) GetSchema(ctx context.Context) schema.Attribute {
@@ -82,6 +110,9 @@ func (m Mounts) GetSchema(ctx context.Context) schema.Attribute {
 		Required:    false,
 		Optional:    true,
 		Computed:    true,
+		Validators: []validator.Set{
+			uniqueMountDestinations{},
+		},
 		PlanModifiers: []planmodifier.Set{
 			modifier.RequiresReplaceComputed(),
 		},
@@ -91,18 +122,17 @@ func (m Mounts) GetSchema(ctx context.Context) schema.Attribute {
 					Description: "Target path",
 					Required:    true,
 					Computed:    false,
-					Validators:  []validator.String{
-						//TODO
+					Validators: []validator.String{
+						validators.MatchAbsolutePath(),
 					},
 				},
+				"options": m.attributeSchemaOptions(),
 				"volume": schema.SingleNestedAttribute{
 					Description: "Named Volume",
 					Optional:    true,
 					Computed:    false,
 					Validators: []validator.Object{
-						objectvalidator.ConflictsWith(
-							path.MatchRelative().AtParent().AtName("bind"),
-						),
+						m.conflictingMountTypes("volume"),
 					},
 					PlanModifiers: []planmodifier.Object{
 						objectplanmodifier.RequiresReplace(),
@@ -133,9 +163,7 @@ func (m Mounts) GetSchema(ctx context.Context) schema.Attribute {
 					Optional:    true,
 					Computed:    false,
 					Validators: []validator.Object{
-						objectvalidator.ConflictsWith(
-							path.MatchRelative().AtParent().AtName("volume"),
-						),
+						m.conflictingMountTypes("bind"),
 					},
 					PlanModifiers: []planmodifier.Object{
 						objectplanmodifier.RequiresReplace(),
@@ -145,8 +173,8 @@ func (m Mounts) GetSchema(ctx context.Context) schema.Attribute {
 							Description: "Host path",
 							Required:    true,
 							Computed:    false,
-							Validators:  []validator.String{
-								// TODO
+							Validators: []validator.String{
+								validators.MatchAbsolutePath(),
 							},
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.RequiresReplace(),
@@ -163,39 +191,147 @@ func (m Mounts) GetSchema(ctx context.Context) schema.Attribute {
 						"relabel":     m.attributeSchemaBindRelabel(),
 					},
 				},
+
+				"tmpfs": schema.SingleNestedAttribute{
+					Description: "Tmpfs Volume",
+					Optional:    true,
+					Computed:    false,
+					Validators: []validator.Object{
+						m.conflictingMountTypes("tmpfs"),
+					},
+					PlanModifiers: []planmodifier.Object{
+						objectplanmodifier.RequiresReplace(),
+					},
+					Attributes: map[string]schema.Attribute{
+						"read_only": m.attributeSchemaReadOnly(),
+						"dev":       m.attributeSchemaDev(),
+						"exec":      m.attributeSchemaExec(),
+						"suid":      m.attributeSchemaSuid(),
+						"chown":     m.attributeSchemaChown(),
+						"size":      m.attributeSchemaTmpfsSize(),
+						"mode":      m.attributeSchemaTmpfsMode(),
+						"tmpcopyup": m.attributeSchemaTmpfsTmpCopyUp(),
+					},
+				},
+
+				"image": schema.SingleNestedAttribute{
+					Description: "Image Volume, mounts the content of an image",
+					Optional:    true,
+					Computed:    false,
+					Validators: []validator.Object{
+						m.conflictingMountTypes("image"),
+					},
+					PlanModifiers: []planmodifier.Object{
+						objectplanmodifier.RequiresReplace(),
+					},
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name or ID of the image",
+							Required:    true,
+							Computed:    false,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"read_only": m.attributeSchemaImageReadOnly(),
+					},
+				},
+
+				"overlay": schema.SingleNestedAttribute{
+					Description: "Overlay Volume, mounts a host path with an overlay. Changes are discarded or written to the upper directory.",
+					Optional:    true,
+					Computed:    false,
+					Validators: []validator.Object{
+						m.conflictingMountTypes("overlay"),
+					},
+					PlanModifiers: []planmodifier.Object{
+						objectplanmodifier.RequiresReplace(),
+					},
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description: "Host path used as lower directory of the overlay",
+							Required:    true,
+							Computed:    false,
+							Validators: []validator.String{
+								validators.MatchAbsolutePath(),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"chown":     m.attributeSchemaOverlayChown(),
+						"upper_dir": m.attributeSchemaOverlayDir("upper", "work_dir"),
+						"work_dir":  m.attributeSchemaOverlayDir("work", "upper_dir"),
+					},
+				},
 			},
 		},
 	}
+}
 
-	// TODO:
-	// While its technically possible, the podman api does not support this case for pods pretty well.
-	// The tmpfs mount will be created on the infra container, but it is not exposed on inspect anymore
-	// https://github.com/containers/podman/blob/v4.3.1/libpod/container_inspect.go#L276-L280
-	//				"tmpfs": {
-	//					Description: "Tmpfs Volume",
-	//					Optional:    true,
-	//					Computed:    false,
-	//					Attributes: tfsdk.SingleNestedAttributes(
-	//						map[string]tfsdk.Attribute{
-	//							"read_only": m.attributeSchemaReadOnly(),
-	//							"dev":       m.attributeSchemaDev(),
-	//							"exec":      m.attributeSchemaExec(),
-	//							"suid":      m.attributeSchemaSuid(),
-	//							"chown":     m.attributeSchemaChown(),
-	//							"size":      m.attributeSchemaTmpfsSize(),
-	//							"mode":      m.attributeSchemaTmpfsMode(),
-	//							"tmpcopyup": m.attributeSchemaTmpfsTmpCopyUp(),
-	//						},
-	//					),
-	//				},
+// conflictingMountTypes ensures only one type is configured per mount
+func (m Mounts) conflictingMountTypes(mountType string) validator.Object {
+	expressions := make([]path.Expression, 0, len(mountTypes)-1)
+	for _, t := range mountTypes {
+		if t != mountType {
+			expressions = append(expressions, path.MatchRelative().AtParent().AtName(t))
+		}
+	}
+	return objectvalidator.ConflictsWith(expressions...)
+}
 
+// uniqueMountDestinations ensures a destination is only used by one mount
+type uniqueMountDestinations struct{}
+
+func (v uniqueMountDestinations) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v uniqueMountDestinations) MarkdownDescription(_ context.Context) string {
+	return "destinations of the mounts must be unique"
 }
 
-// ToPodmanSpec creates volume and mounts
-func (m Mounts) ToPodmanSpec(diags *diag.Diagnostics) ([]*specgen.NamedVolume, []specs.Mount) {
+func (v uniqueMountDestinations) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	destinations := make(map[string]bool)
+	for _, element := range req.ConfigValue.Elements() {
+		destination, ok := mountDestination(element)
+		if !ok {
+			continue
+		}
+		if destinations[destination] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(element).AtName("destination"),
+				"Duplicate mount destination",
+				fmt.Sprintf("The destination %s is used by multiple mounts.", destination),
+			)
+		}
+		destinations[destination] = true
+	}
+}
+
+// mountDestination returns the known destination of a mount object
+func mountDestination(element attr.Value) (string, bool) {
+	obj, ok := element.(types.Object)
+	if !ok || obj.IsNull() || obj.IsUnknown() {
+		return "", false
+	}
+	destination, ok := obj.Attributes()["destination"].(types.String)
+	if !ok || destination.IsNull() || destination.IsUnknown() {
+		return "", false
+	}
+	return destination.ValueString(), true
+}
+
+// ToPodmanSpec creates volume, mounts, overlay and image volumes
+func (m Mounts) ToPodmanSpec(diags *diag.Diagnostics) ([]*specgen.NamedVolume, []specs.Mount, []*specgen.OverlayVolume, []*specgen.ImageVolume) {
 
 	specNamedVolumes := make([]*specgen.NamedVolume, 0)
 	specMounts := make([]specs.Mount, 0)
+	specOverlayVolumes := make([]*specgen.OverlayVolume, 0)
+	specImageVolumes := make([]*specgen.ImageVolume, 0)
 	for _, mount := range m {
 		if mount.Volume != nil {
 			// Named volume mount options
@@ -248,43 +384,86 @@ func (m Mounts) ToPodmanSpec(diags *diag.Diagnostics) ([]*specgen.NamedVolume, [
 			specMount.Options = appendMountOptBool(specMount.Options, mount.Bind.Relabel, "z", "Z")
 
 			specMounts = append(specMounts, specMount)
+		} else if mount.Tmpfs != nil {
+			// Tmpfs mount options
+			specMount := specs.Mount{
+				Destination: mount.Destination.ValueString(),
+				Type:        "tmpfs",
+				Source:      "tmpfs",
+			}
+
+			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.ReadOnly, "ro", "rw")
+			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.Dev, "dev", "nodev")
+			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.Exec, "exec", "noexec")
+			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.Suid, "suid", "nosuid")
+
+			if mount.Tmpfs.Chown.ValueBool() {
+				specMount.Options = append(specMount.Options, "U")
+			}
+
+			if mount.Tmpfs.Size.ValueString() != "" {
+				specMount.Options = append(specMount.Options, "size="+mount.Tmpfs.Size.ValueString())
+			}
+			if mount.Tmpfs.Mode.ValueString() != "" {
+				specMount.Options = append(specMount.Options, "mode="+mount.Tmpfs.Mode.ValueString())
+			}
+			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.TmpCopyUp, "tmpcopyup", "notmpcopyup")
+
+			specMounts = append(specMounts, specMount)
+		} else if mount.Image != nil {
+			specImageVolumes = append(specImageVolumes, &specgen.ImageVolume{
+				Source:      mount.Image.Name.ValueString(),
+				Destination: mount.Destination.ValueString(),
+				ReadWrite:   !mount.Image.ReadOnly.IsNull() && !mount.Image.ReadOnly.IsUnknown() && !mount.Image.ReadOnly.ValueBool(),
+			})
+		} else if mount.Overlay != nil {
+			// Overlay volume options
+			specOverlay := specgen.OverlayVolume{
+				Destination: mount.Destination.ValueString(),
+				Source:      mount.Overlay.Path.ValueString(),
+				Options:     []string{"O"},
+			}
+
+			if mount.Overlay.Chown.ValueBool() {
+				specOverlay.Options = append(specOverlay.Options, "U")
+			}
+
+			if mount.Overlay.UpperDir.ValueString() != "" {
+				specOverlay.Options = append(specOverlay.Options,
+					"upperdir="+mount.Overlay.UpperDir.ValueString(),
+					"workdir="+mount.Overlay.WorkDir.ValueString(),
+				)
+			}
+
+			specOverlayVolumes = append(specOverlayVolumes, &specOverlay)
 		}
-		// else if mount.Tmpfs != nil {
-		//
-		// 			// Tmpfs mount options
-		// 			specMount := specs.Mount{
-		// 				Destination: mount.Destination.ValueString(),
-		// 				Type:        "tmpfs",
-		// 			}
-		//
-		// 			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.ReadOnly, "ro", "rw")
-		// 			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.Dev, "dev", "nodev")
-		// 			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.Exec, "exec", "noexec")
-		// 			specMount.Options = appendMountOptBool(specMount.Options, mount.Tmpfs.Suid, "suid", "nosuid")
-		//
-		// 			if mount.Tmpfs.Chown.ValueBool() {
-		// 				specMount.Options = append(specMount.Options, "U")
-		// 			}
-		//
-		// 			specMounts = append(specMounts, specMount)
-		// 		}
 	}
-	return specNamedVolumes, specMounts
+	return specNamedVolumes, specMounts, specOverlayVolumes, specImageVolumes
 }
 
+// FromPodmanToMounts converts the mounts of a container, options not reported are set to the podman defaults
 func FromPodmanToMounts(diags *diag.Diagnostics, specMounts []define.InspectMount) Mounts {
 	mounts := make(Mounts, 0)
 
 	for _, specMount := range specMounts {
-		opts := parseMountOptions(diags, specMount.Options)
+		opts := parseMountOptions(specMount.Options)
 		if opts.readOnly.IsNull() {
 			opts.readOnly = types.BoolValue(!specMount.RW)
 		}
+		// inspect reports the propagation and relabel option separately
+		if specMount.Propagation != "" {
+			opts.propagation = types.StringValue(specMount.Propagation)
+		}
+		if specMount.Mode == "z" || specMount.Mode == "Z" {
+			opts.relabel = types.BoolValue(specMount.Mode == "z")
+		}
+		opts = opts.withDefaults(defaultMountOptions(specMount.Type))
 
 		switch specMount.Type {
 		case "volume":
 			mounts = append(mounts, Mount{
 				Destination: types.StringValue(specMount.Destination),
+				Options:     opts.unknownOptions(),
 				Volume: &MountVolume{
 					Name:     types.StringValue(specMount.Name),
 					ReadOnly: opts.readOnly,
@@ -299,6 +478,7 @@ func FromPodmanToMounts(diags *diag.Diagnostics, specMounts []define.InspectMoun
 		case "bind":
 			mounts = append(mounts, Mount{
 				Destination: types.StringValue(specMount.Destination),
+				Options:     opts.unknownOptions(),
 				Bind: &MountBind{
 					Path:        types.StringValue(specMount.Source),
 					ReadOnly:    opts.readOnly,
@@ -313,18 +493,15 @@ func FromPodmanToMounts(diags *diag.Diagnostics, specMounts []define.InspectMoun
 				},
 			})
 
-			//		case "tmpfs":
-			//			mounts = append(mounts, Mount{
-			//				Destination: types.String{Value: specMount.Destination},
-			//				Tmpfs: &MountTmpfs{
-			//					ReadOnly: opts.readOnly,
-			//					Dev:      opts.dev,
-			//					Exec:     opts.exec,
-			//					Suid:     opts.suid,
-			//					Chown:    opts.chown,
-			//					Size:     opts.size,
-			//				},
-			//			})
+		case "image":
+			mounts = append(mounts, Mount{
+				Destination: types.StringValue(specMount.Destination),
+				Options:     types.ListNull(types.StringType),
+				Image: &MountImage{
+					Name:     types.StringValue(specMount.Source),
+					ReadOnly: types.BoolValue(!specMount.RW),
+				},
+			})
 
 		default:
 			diags.AddError("Unknown mount type retrieved", specMount.Type)
@@ -336,6 +513,68 @@ func FromPodmanToMounts(diags *diag.Diagnostics, specMounts []define.InspectMoun
 	return mounts
 }
 
+// FromPodmanTmpfsToMounts converts the tmpfs mounts of a container, inspect reports them with the joined options by destination
+func FromPodmanTmpfsToMounts(tmpfs map[string]string) Mounts {
+	destinations := make([]string, 0, len(tmpfs))
+	for destination := range tmpfs {
+		destinations = append(destinations, destination)
+	}
+	sort.Strings(destinations)
+
+	mounts := make(Mounts, 0, len(tmpfs))
+	for _, destination := range destinations {
+		var options []string
+		if tmpfs[destination] != "" {
+			options = strings.Split(tmpfs[destination], ",")
+		}
+		opts := parseMountOptions(options).withDefaults(defaultMountOptions("tmpfs"))
+
+		mounts = append(mounts, Mount{
+			Destination: types.StringValue(destination),
+			Options:     opts.unknownOptions(),
+			Tmpfs: &MountTmpfs{
+				ReadOnly:  opts.readOnly,
+				Dev:       opts.dev,
+				Exec:      opts.exec,
+				Suid:      opts.suid,
+				Chown:     opts.chown,
+				Size:      opts.size,
+				Mode:      opts.mode,
+				TmpCopyUp: opts.tmpcopyup,
+			},
+		})
+	}
+	return mounts
+}
+
+// WithOverlayMounts adds the overlay mounts of the prior mounts.
+// Overlays are not reported by inspect, once the container is started they are reported as bind mount of the merged directory.
+func (m Mounts) WithOverlayMounts(prior Mounts) Mounts {
+	overlays := make(map[string]Mount)
+	for _, mount := range prior {
+		if mount.Overlay != nil {
+			overlays[mount.Destination.ValueString()] = mount
+		}
+	}
+	if len(overlays) == 0 {
+		return m
+	}
+
+	mounts := make(Mounts, 0, len(m)+len(overlays))
+	for _, mount := range m {
+		if _, ok := overlays[mount.Destination.ValueString()]; ok && mount.Bind != nil {
+			continue
+		}
+		mounts = append(mounts, mount)
+	}
+	for _, mount := range prior {
+		if mount.Overlay != nil {
+			mounts = append(mounts, mount)
+		}
+	}
+	return mounts
+}
+
 // appendMountOptBool appends a mapped boolen value
 func appendMountOptBool(opts []string, v types.Bool, trueVal string, falseVal string) []string {
 	if !v.IsNull() {
@@ -0,0 +1,142 @@
+package shared
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/docker/go-units"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// mountDefaults are the effective options of the mount types when podman does not report them.
+	// Podman reports the options it applies by default (e.g. rbind, nosuid, nodev),
+	// a missing option means the flag is not set on the mount.
+	// The defaults are the same for all podman versions supported by the provider.
+	mountDefaults = map[string]allMountOptions{
+		"volume": {
+			readOnly: types.BoolValue(false),
+			dev:      types.BoolValue(true),
+			exec:     types.BoolValue(true),
+			suid:     types.BoolValue(true),
+		},
+		"bind": {
+			readOnly:    types.BoolValue(false),
+			dev:         types.BoolValue(true),
+			exec:        types.BoolValue(true),
+			suid:        types.BoolValue(true),
+			recursive:   types.BoolValue(true),
+			propagation: types.StringValue(bindPropagationPrivateRecursive),
+		},
+		"tmpfs": {
+			readOnly: types.BoolValue(false),
+			dev:      types.BoolValue(true),
+			exec:     types.BoolValue(true),
+			suid:     types.BoolValue(true),
+			// notmpcopyup is not passed to the runtime
+			tmpcopyup: types.BoolValue(false),
+		},
+	}
+)
+
+// defaultMountOptions returns the effective defaults of the mount type
+func defaultMountOptions(mountType string) allMountOptions {
+	return mountDefaults[mountType]
+}
+
+// withDefaults sets the options not reported by podman to the effective defaults
+func (o allMountOptions) withDefaults(defaults allMountOptions) allMountOptions {
+	o.readOnly = boolOrDefault(o.readOnly, defaults.readOnly)
+	o.dev = boolOrDefault(o.dev, defaults.dev)
+	o.exec = boolOrDefault(o.exec, defaults.exec)
+	o.suid = boolOrDefault(o.suid, defaults.suid)
+	o.recursive = boolOrDefault(o.recursive, defaults.recursive)
+	o.tmpcopyup = boolOrDefault(o.tmpcopyup, defaults.tmpcopyup)
+	if o.propagation.IsNull() && !defaults.propagation.IsNull() && !defaults.propagation.IsUnknown() {
+		o.propagation = defaults.propagation
+	}
+	return o
+}
+
+// unknownOptions returns the preserved unknown options
+func (o allMountOptions) unknownOptions() types.List {
+	if len(o.unknown) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	values := make([]attr.Value, 0, len(o.unknown))
+	for _, u := range o.unknown {
+		values = append(values, types.StringValue(u))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+func boolOrDefault(v types.Bool, d types.Bool) types.Bool {
+	if v.IsNull() && !d.IsNull() && !d.IsUnknown() {
+		return d
+	}
+	return v
+}
+
+// Normalize keeps the representation of the prior mounts if the retrieved values are semantically equal,
+// e.g. a tmpfs size of 1g is reported as 1024m by some podman versions.
+func (m Mounts) Normalize(prior Mounts) Mounts {
+	priorMounts := make(map[string]Mount, len(prior))
+	for _, p := range prior {
+		priorMounts[p.Destination.ValueString()] = p
+	}
+
+	mounts := make(Mounts, 0, len(m))
+	for _, mount := range m {
+		p, ok := priorMounts[mount.Destination.ValueString()]
+		if !ok {
+			mounts = append(mounts, mount)
+			continue
+		}
+
+		switch {
+		case mount.Bind != nil && p.Bind != nil:
+			bind := *mount.Bind
+			bind.Path = keepPrior(bind.Path, p.Bind.Path, equalPath)
+			mount.Bind = &bind
+
+		case mount.Tmpfs != nil && p.Tmpfs != nil:
+			tmpfs := *mount.Tmpfs
+			tmpfs.Size = keepPrior(tmpfs.Size, p.Tmpfs.Size, equalSize)
+			tmpfs.Mode = keepPrior(tmpfs.Mode, p.Tmpfs.Mode, equalMode)
+			mount.Tmpfs = &tmpfs
+		}
+		mounts = append(mounts, mount)
+	}
+	if len(mounts) == 0 {
+		return nil
+	}
+	return mounts
+}
+
+// keepPrior returns the prior value if it is semantically equal to the retrieved value
+func keepPrior(v types.String, prior types.String, equal func(a, b string) bool) types.String {
+	if v.IsNull() || v.IsUnknown() || prior.IsNull() || prior.IsUnknown() {
+		return v
+	}
+	if equal(v.ValueString(), prior.ValueString()) {
+		return prior
+	}
+	return v
+}
+
+func equalPath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+func equalSize(a, b string) bool {
+	sizeA, errA := units.RAMInBytes(a)
+	sizeB, errB := units.RAMInBytes(b)
+	return errA == nil && errB == nil && sizeA == sizeB
+}
+
+func equalMode(a, b string) bool {
+	modeA, errA := strconv.ParseUint(a, 8, 32)
+	modeB, errB := strconv.ParseUint(b, 8, 32)
+	return errA == nil && errB == nil && modeA == modeB
+}
@@ -0,0 +1,237 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefaultMountOptions(t *testing.T) {
+	tests := []struct {
+		desc      string
+		mountType string
+		want      allMountOptions
+	}{
+		{
+			desc:      "Volume defaults",
+			mountType: "volume",
+			want: allMountOptions{
+				readOnly: types.BoolValue(false),
+				dev:      types.BoolValue(true),
+				exec:     types.BoolValue(true),
+				suid:     types.BoolValue(true),
+			},
+		},
+		{
+			desc:      "Bind defaults are recursive and private",
+			mountType: "bind",
+			want: allMountOptions{
+				readOnly:    types.BoolValue(false),
+				dev:         types.BoolValue(true),
+				exec:        types.BoolValue(true),
+				suid:        types.BoolValue(true),
+				recursive:   types.BoolValue(true),
+				propagation: types.StringValue(bindPropagationPrivateRecursive),
+			},
+		},
+		{
+			desc:      "Tmpfs defaults do not copy up",
+			mountType: "tmpfs",
+			want: allMountOptions{
+				readOnly:  types.BoolValue(false),
+				dev:       types.BoolValue(true),
+				exec:      types.BoolValue(true),
+				suid:      types.BoolValue(true),
+				tmpcopyup: types.BoolValue(false),
+			},
+		},
+		{
+			desc:      "Image mounts have no defaults",
+			mountType: "image",
+			want:      allMountOptions{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := defaultMountOptions(test.mountType); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %+v, want %+v", test.desc, got, test.want)
+			}
+		})
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		desc     string
+		options  []string
+		defaults allMountOptions
+		want     func(o *allMountOptions)
+	}{
+		{
+			desc:     "Missing options are set to the defaults",
+			defaults: defaultMountOptions("bind"),
+			want: func(o *allMountOptions) {
+				o.readOnly = types.BoolValue(false)
+				o.dev = types.BoolValue(true)
+				o.exec = types.BoolValue(true)
+				o.suid = types.BoolValue(true)
+				o.recursive = types.BoolValue(true)
+				o.propagation = types.StringValue(bindPropagationPrivateRecursive)
+			},
+		},
+		{
+			desc:     "Reported options are kept",
+			options:  []string{"ro", "nodev", "noexec", "nosuid", "bind", "rshared"},
+			defaults: defaultMountOptions("bind"),
+			want: func(o *allMountOptions) {
+				o.readOnly = types.BoolValue(true)
+				o.dev = types.BoolValue(false)
+				o.exec = types.BoolValue(false)
+				o.suid = types.BoolValue(false)
+				o.recursive = types.BoolValue(false)
+				o.propagation = types.StringValue(bindPropagationSharedRecursive)
+			},
+		},
+		{
+			desc:     "Tmpfs copy up defaults to false",
+			options:  []string{"size=64m"},
+			defaults: defaultMountOptions("tmpfs"),
+			want: func(o *allMountOptions) {
+				o.readOnly = types.BoolValue(false)
+				o.dev = types.BoolValue(true)
+				o.exec = types.BoolValue(true)
+				o.suid = types.BoolValue(true)
+				o.size = types.StringValue("64m")
+				o.tmpcopyup = types.BoolValue(false)
+			},
+		},
+		{
+			desc:     "No defaults",
+			options:  []string{"ro"},
+			defaults: defaultMountOptions("image"),
+			want: func(o *allMountOptions) {
+				o.readOnly = types.BoolValue(true)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			want := newAllMountOptions()
+			test.want(&want)
+			got := parseMountOptions(test.options).withDefaults(test.defaults)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: got %+v, want %+v", test.desc, got, want)
+			}
+		})
+	}
+}
+
+func TestMountsNormalize(t *testing.T) {
+	tmpfs := func(destination, size, mode string) Mount {
+		return testTmpfsMount(destination, func(m *MountTmpfs) {
+			m.Size = types.StringValue(size)
+			m.Mode = types.StringValue(mode)
+		})
+	}
+
+	tests := []struct {
+		desc   string
+		mounts Mounts
+		prior  Mounts
+		want   Mounts
+	}{
+		{
+			desc: "No mounts",
+			want: nil,
+		},
+		{
+			desc:   "Mounts without prior",
+			mounts: Mounts{tmpfs("/run", "1024m", "1777")},
+			want:   Mounts{tmpfs("/run", "1024m", "1777")},
+		},
+		{
+			desc:   "Equal tmpfs size and mode keep the prior representation",
+			mounts: Mounts{tmpfs("/run", "1024m", "1777")},
+			prior:  Mounts{tmpfs("/run", "1g", "01777")},
+			want:   Mounts{tmpfs("/run", "1g", "01777")},
+		},
+		{
+			desc:   "Changed tmpfs size and mode are reported",
+			mounts: Mounts{tmpfs("/run", "512m", "755")},
+			prior:  Mounts{tmpfs("/run", "1g", "1777")},
+			want:   Mounts{tmpfs("/run", "512m", "755")},
+		},
+		{
+			desc:   "Equal bind path keeps the prior representation",
+			mounts: Mounts{testBindMount("/srv/data", "/data", nil)},
+			prior:  Mounts{testBindMount("/srv/data/", "/data", nil)},
+			want:   Mounts{testBindMount("/srv/data/", "/data", nil)},
+		},
+		{
+			desc:   "Prior mount of another type is not used",
+			mounts: Mounts{testBindMount("/srv", "/data", nil)},
+			prior:  Mounts{tmpfs("/data", "1g", "1777")},
+			want:   Mounts{testBindMount("/srv", "/data", nil)},
+		},
+		{
+			desc:   "Prior mount of another destination is not used",
+			mounts: Mounts{tmpfs("/run", "1024m", "1777")},
+			prior:  Mounts{tmpfs("/tmp", "1g", "1777")},
+			want:   Mounts{tmpfs("/run", "1024m", "1777")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := test.mounts.Normalize(test.prior); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %+v, want %+v", test.desc, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEqualSize(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1g", "1024m", true},
+		{"1G", "1073741824", true},
+		{"64k", "65536", true},
+		{"512m", "1g", false},
+		{"1g", "invalid", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"="+test.b, func(t *testing.T) {
+			if got := equalSize(test.a, test.b); got != test.want {
+				t.Errorf("equalSize(%q, %q): got %t, want %t", test.a, test.b, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEqualMode(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1777", "01777", true},
+		{"755", "0755", true},
+		{"755", "750", false},
+		{"755", "9", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"="+test.b, func(t *testing.T) {
+			if got := equalMode(test.a, test.b); got != test.want {
+				t.Errorf("equalMode(%q, %q): got %t, want %t", test.a, test.b, got, test.want)
+			}
+		})
+	}
+}
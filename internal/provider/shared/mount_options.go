@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/project0/terraform-provider-podman/internal/modifier"
+	"github.com/project0/terraform-provider-podman/internal/validators"
 )
 
 const (
@@ -154,50 +158,102 @@ func (m Mounts) attributeSchemaBindRelabel() schema.Attribute {
 	}
 }
 
-// func (m Mounts) attributeSchemaTmpfsSize() schema.Attribute{
-// 	return tfsdk.Attribute{
-// 		Description: "Size of the tmpfs mount in bytes or units. Unlimited by default in Linux.",
-// 		Computed:    true,
-// 		Optional:    true,
-// 		Type:        types.StringType,
-// 		Validators: []tfsdk.AttributeValidator{
-// 			validator.MatchTmpfSize(),
-// 		},
-// 		PlanModifiers: tfsdk.AttributePlanModifiers{
-// 			modifier.AlwaysUseStateForUnknown(),
-// 			modifier.RequiresReplaceComputed(),
-// 		},
-// 	}
-// }
-//
-// func (m Mounts) attributeSchemaTmpfsMode() schema.Attribute{
-// 	return tfsdk.Attribute{
-// 		Description: "File mode of the tmpfs in octal (e.g. 700 or 0700). Defaults to 1777 in Linux.",
-// 		Computed:    true,
-// 		Optional:    true,
-// 		Type:        types.StringType,
-// 		Validators: []tfsdk.AttributeValidator{
-// 			validator.MatchOctal(),
-// 		},
-// 		PlanModifiers: tfsdk.AttributePlanModifiers{
-// 			modifier.AlwaysUseStateForUnknown(),
-// 			modifier.RequiresReplaceComputed(),
-// 		},
-// 	}
-// }
-//
-// func (m Mounts) attributeSchemaTmpfsTmpCopyUp() schema.Attribute{
-// 	return tfsdk.Attribute{
-// 		Description: "Enable copyup from the image directory at the same location to the tmpfs. Used by default.",
-// 		Computed:    true,
-// 		Optional:    true,
-// 		Type:        types.BoolType,
-// 		PlanModifiers: tfsdk.AttributePlanModifiers{
-// 			modifier.AlwaysUseStateForUnknown(),
-// 			modifier.RequiresReplaceComputed(),
-// 		},
-// 	}
-// }
+func (m Mounts) attributeSchemaTmpfsSize() schema.Attribute {
+	return schema.StringAttribute{
+		Description: "Size of the tmpfs mount in bytes or units. Unlimited by default in Linux.",
+		Computed:    true,
+		Optional:    true,
+		Validators: []validator.String{
+			validators.MatchTmpfSize(),
+		},
+		PlanModifiers: []planmodifier.String{
+			modifier.AlwaysUseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+	}
+}
+
+func (m Mounts) attributeSchemaTmpfsMode() schema.Attribute {
+	return schema.StringAttribute{
+		Description: "File mode of the tmpfs in octal (e.g. 700 or 0700). Defaults to 1777 in Linux.",
+		Computed:    true,
+		Optional:    true,
+		Validators: []validator.String{
+			validators.MatchOctal(),
+		},
+		PlanModifiers: []planmodifier.String{
+			modifier.AlwaysUseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+	}
+}
+
+func (m Mounts) attributeSchemaTmpfsTmpCopyUp() schema.Attribute {
+	return schema.BoolAttribute{
+		Description: "Enable copyup from the image directory at the same location to the tmpfs. Used by default.",
+		Computed:    true,
+		Optional:    true,
+		PlanModifiers: []planmodifier.Bool{
+			modifier.AlwaysUseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+	}
+}
+
+func (m Mounts) attributeSchemaOptions() schema.Attribute {
+	return schema.ListAttribute{
+		Description: "Mount options reported by podman which are not covered by the attributes of the mount type.",
+		Computed:    true,
+		ElementType: types.StringType,
+		PlanModifiers: []planmodifier.List{
+			modifier.AlwaysUseStateForUnknown(),
+		},
+	}
+}
+
+func (m Mounts) attributeSchemaImageReadOnly() schema.Attribute {
+	return schema.BoolAttribute{
+		Description: "Mount as read only. Image volumes are read only by default.",
+		Computed:    true,
+		Optional:    true,
+		PlanModifiers: []planmodifier.Bool{
+			modifier.AlwaysUseStateForUnknown(),
+			modifier.RequiresReplaceComputed(),
+		},
+	}
+}
+
+func (m Mounts) attributeSchemaOverlayChown() schema.Attribute {
+	return schema.BoolAttribute{
+		Description: "Change recursively the owner and group of the source volume based on the UID and GID of the container. " +
+			"Cannot be used with upper and work directory.",
+		Optional: true,
+		Validators: []validator.Bool{
+			boolvalidator.ConflictsWith(
+				path.MatchRelative().AtParent().AtName("upper_dir"),
+				path.MatchRelative().AtParent().AtName("work_dir"),
+			),
+		},
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func (m Mounts) attributeSchemaOverlayDir(dir string, requires string) schema.Attribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("Host path of the %s directory of the overlay, changes are persisted in the upper directory. ", dir) +
+			"Upper and work directory must be set together.",
+		Optional: true,
+		Validators: []validator.String{
+			validators.MatchAbsolutePath(),
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName(requires)),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
 
 type allMountOptions struct {
 	readOnly types.Bool
@@ -214,10 +270,17 @@ type allMountOptions struct {
 	size      types.String
 	mode      types.String
 	tmpcopyup types.Bool
+	// overlay
+	overlay  types.Bool
+	upperDir types.String
+	workDir  types.String
+
+	// unknown are the options not covered by the attributes
+	unknown []string
 }
 
-func parseMountOptions(diags *diag.Diagnostics, options []string) allMountOptions {
-	result := allMountOptions{
+func newAllMountOptions() allMountOptions {
+	return allMountOptions{
 		readOnly: types.BoolNull(),
 		dev:      types.BoolNull(),
 		exec:     types.BoolNull(),
@@ -237,59 +300,87 @@ func parseMountOptions(diags *diag.Diagnostics, options []string) allMountOption
 		size:      types.StringNull(),
 		mode:      types.StringNull(),
 		tmpcopyup: types.BoolNull(),
+
+		// overlay
+		overlay:  types.BoolValue(false),
+		upperDir: types.StringNull(),
+		workDir:  types.StringNull(),
 	}
+}
 
+// parseMountOptions parses the retrieved mount options, unknown options are preserved
+func parseMountOptions(options []string) allMountOptions {
+	result := newAllMountOptions()
 	for _, o := range options {
+		if !result.parse(o) {
+			result.unknown = append(result.unknown, o)
+		}
+	}
+	return result
+}
 
-		switch o {
-		case "ro", "rw":
-			result.readOnly = types.BoolValue((o == "ro"))
+// parse sets a single mount option, unknown options are reported as false
+func (result *allMountOptions) parse(o string) bool {
+	// size, mode and the overlay directories have a value
+	key, value, _ := strings.Cut(o, "=")
 
-		case "dev", "nodev":
-			result.dev = types.BoolValue((o == "dev"))
+	switch key {
+	case "ro", "rw":
+		result.readOnly = types.BoolValue((o == "ro"))
 
-		case "exec", "noexec":
-			result.exec = types.BoolValue((o == "exec"))
+	case "dev", "nodev":
+		result.dev = types.BoolValue((o == "dev"))
 
-		case "suid", "nosuid":
-			result.suid = types.BoolValue((o == "suid"))
+	case "exec", "noexec":
+		result.exec = types.BoolValue((o == "exec"))
 
-		case "bind", "rbind":
-			result.recursive = types.BoolValue((o == "rbind"))
+	case "suid", "nosuid":
+		result.suid = types.BoolValue((o == "suid"))
 
-		case "tmpcopyup", "notmpcopyup":
-			result.recursive = types.BoolValue((o == "tmpcopyup"))
+	case "bind", "rbind":
+		result.recursive = types.BoolValue((o == "rbind"))
 
-		// public = z (relabel), private = Z (no relabel)
-		case "z", "Z":
-			result.relabel = types.BoolValue((o == "z"))
+	case "tmpcopyup", "notmpcopyup":
+		result.tmpcopyup = types.BoolValue((o == "tmpcopyup"))
 
-		case "U":
-			result.chown = types.BoolValue(true)
+	// public = z (relabel), private = Z (no relabel)
+	case "z", "Z":
+		result.relabel = types.BoolValue((o == "z"))
 
-		case "idmap":
-			result.idmap = types.BoolValue(true)
+	case "U":
+		result.chown = types.BoolValue(true)
 
-		case "size":
-			result.size = types.StringValue(o)
+	case "idmap":
+		result.idmap = types.BoolValue(true)
 
-		case "mode":
-			result.mode = types.StringValue(o)
+	case "size":
+		result.size = types.StringValue(value)
 
-		case
-			bindPropagationShared,
-			bindPropagationSlave,
-			bindPropagationPrivate,
-			bindPropagationUnbindable,
-			bindPropagationSharedRecursive,
-			bindPropagationSlaveRecursive,
-			bindPropagationPrivateRecursive,
-			bindPropagationUnbindableRecursive:
-			result.propagation = types.StringValue(o)
+	case "mode":
+		result.mode = types.StringValue(value)
 
-		default:
-			diags.AddWarning("Unknown mount option retrieved", o)
-		}
+	case "O":
+		result.overlay = types.BoolValue(true)
+
+	case "upperdir":
+		result.upperDir = types.StringValue(value)
+
+	case "workdir":
+		result.workDir = types.StringValue(value)
+
+	case
+		bindPropagationShared,
+		bindPropagationSlave,
+		bindPropagationPrivate,
+		bindPropagationUnbindable,
+		bindPropagationSharedRecursive,
+		bindPropagationSlaveRecursive,
+		bindPropagationPrivateRecursive,
+		bindPropagationUnbindableRecursive:
+		result.propagation = types.StringValue(o)
+
+	default:
+		return false
 	}
-	return result
+	return true
 }
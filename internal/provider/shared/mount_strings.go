@@ -0,0 +1,413 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// mountFlagTypes are the mount types supporting the specific keys of the --mount flag
+	mountFlagTypes = map[string]string{
+		"relabel":           "bind",
+		"bind-propagation":  "bind",
+		"bind-nonrecursive": "bind",
+		"tmpfs-size":        "tmpfs",
+		"tmpfs-mode":        "tmpfs",
+	}
+
+	// mountTypeOptions are the options supported by the mount types
+	mountTypeOptions = map[string][]string{
+		"volume": {"ro", "rw", "dev", "nodev", "exec", "noexec", "suid", "nosuid", "U", "idmap"},
+		"bind": {
+			"ro", "rw", "dev", "nodev", "exec", "noexec", "suid", "nosuid", "U", "idmap",
+			"bind", "rbind", "z", "Z",
+			bindPropagationShared,
+			bindPropagationSlave,
+			bindPropagationPrivate,
+			bindPropagationUnbindable,
+			bindPropagationSharedRecursive,
+			bindPropagationSlaveRecursive,
+			bindPropagationPrivateRecursive,
+			bindPropagationUnbindableRecursive,
+		},
+		"tmpfs":   {"ro", "rw", "dev", "nodev", "exec", "noexec", "suid", "nosuid", "U", "size", "mode", "tmpcopyup", "notmpcopyup"},
+		"image":   {"ro", "rw"},
+		"overlay": {"O", "U", "upperdir", "workdir"},
+	}
+)
+
+// mountStringsValidator validates the mount strings can be parsed
+type mountStringsValidator struct{}
+
+func (v mountStringsValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v mountStringsValidator) MarkdownDescription(_ context.Context) string {
+	return "values must be mounts in the format of the podman --mount or --volume flags"
+}
+
+func (v mountStringsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	destinations := make(map[string]bool)
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		mount, err := ParseMountString(value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid mount string", err.Error())
+			continue
+		}
+		destination := mount.Destination.ValueString()
+		if destinations[destination] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Duplicate mount destination",
+				fmt.Sprintf("The destination %s is used by multiple mounts.", destination),
+			)
+		}
+		destinations[destination] = true
+	}
+}
+
+// ValidateMountStringDestinations ensures the mount strings do not use a destination of the mounts
+func ValidateMountStringDestinations(mounts types.Set, mountStrings types.List, p path.Path, diags *diag.Diagnostics) {
+	if mounts.IsNull() || mounts.IsUnknown() || mountStrings.IsNull() || mountStrings.IsUnknown() {
+		return
+	}
+	destinations := make(map[string]bool)
+	for _, element := range mounts.Elements() {
+		if destination, ok := mountDestination(element); ok {
+			destinations[destination] = true
+		}
+	}
+	for i, element := range mountStrings.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		// invalid mount strings are reported by the attribute validator
+		mount, err := ParseMountString(value.ValueString())
+		if err != nil {
+			continue
+		}
+		if destinations[mount.Destination.ValueString()] {
+			diags.AddAttributeError(
+				p.AtListIndex(i),
+				"Duplicate mount destination",
+				fmt.Sprintf("The destination %s is already used by the mounts.", mount.Destination.ValueString()),
+			)
+		}
+	}
+}
+
+func (m Mounts) GetStringsSchema(ctx context.Context) schema.Attribute {
+	return schema.ListAttribute{
+		MarkdownDescription: "Mounts in the format of the podman `--mount` flag, e.g. `type=bind,src=/srv,dst=/data,ro,z`, " +
+			"or the `--volume` flag, e.g. `name:/data:ro,U`. Supports the same mount types and options as `mounts`.",
+		Optional:    true,
+		ElementType: types.StringType,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			mountStringsValidator{},
+		},
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// MountsFromStrings converts the mount strings, errors are reported at the list index of the attribute path
+func MountsFromStrings(ctx context.Context, values types.List, p path.Path, diags *diag.Diagnostics) Mounts {
+	if values.IsNull() || values.IsUnknown() {
+		return nil
+	}
+	var mountStrings []string
+	diags.Append(values.ElementsAs(ctx, &mountStrings, false)...)
+
+	mounts := make(Mounts, 0, len(mountStrings))
+	for i, value := range mountStrings {
+		mount, err := ParseMountString(value)
+		if err != nil {
+			diags.AddAttributeError(p.AtListIndex(i), "Invalid mount string", err.Error())
+			continue
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts
+}
+
+// WithoutMountStrings removes the reported mounts created by the prior mount strings.
+// The mount strings are kept as long as their destination is still mounted, overlays are never reported and always kept.
+func (m Mounts) WithoutMountStrings(ctx context.Context, prior types.List, diags *diag.Diagnostics) (Mounts, types.List) {
+	if prior.IsNull() || prior.IsUnknown() {
+		return m, types.ListNull(types.StringType)
+	}
+	var priorStrings []string
+	diags.Append(prior.ElementsAs(ctx, &priorStrings, false)...)
+
+	destinations := make(map[string]bool, len(m))
+	for _, mount := range m {
+		destinations[mount.Destination.ValueString()] = true
+	}
+
+	stringDestinations := make(map[string]bool, len(priorStrings))
+	values := make([]string, 0, len(priorStrings))
+	for _, value := range priorStrings {
+		mount, err := ParseMountString(value)
+		if err != nil {
+			continue
+		}
+		destination := mount.Destination.ValueString()
+		if mount.Overlay != nil || destinations[destination] {
+			stringDestinations[destination] = true
+			values = append(values, value)
+		}
+	}
+
+	mounts := make(Mounts, 0, len(m))
+	for _, mount := range m {
+		if !stringDestinations[mount.Destination.ValueString()] {
+			mounts = append(mounts, mount)
+		}
+	}
+	if len(mounts) == 0 {
+		mounts = nil
+	}
+
+	if len(values) == 0 {
+		return mounts, types.ListNull(types.StringType)
+	}
+	list, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return mounts, list
+}
+
+// ParseMountString parses a mount in the format of the podman --mount flag (type=bind,src=/src,dst=/dst,ro)
+// or the --volume flag (src:/dst:ro)
+func ParseMountString(value string) (Mount, error) {
+	for _, field := range strings.Split(value, ",") {
+		if strings.HasPrefix(field, "type=") {
+			return parseMountFlag(value)
+		}
+	}
+	return parseVolumeFlag(value)
+}
+
+// parseMountFlag parses the format of the --mount flag and maps the keys to the mount options
+func parseMountFlag(value string) (Mount, error) {
+	var mountType, source, destination string
+	var options, keys []string
+	for _, field := range strings.Split(value, ",") {
+		key, v, hasValue := strings.Cut(field, "=")
+		keys = append(keys, key)
+		switch key {
+		case "type":
+			mountType = v
+		case "src", "source":
+			source = v
+		case "dst", "destination", "target":
+			destination = v
+		case "ro", "readonly", "rw":
+			enabled := true
+			if hasValue {
+				b, err := strconv.ParseBool(v)
+				if err != nil {
+					return Mount{}, fmt.Errorf("invalid value of mount option %q: %s", field, err.Error())
+				}
+				enabled = b
+			}
+			if enabled == (key == "rw") {
+				options = append(options, "rw")
+			} else {
+				options = append(options, "ro")
+			}
+		case "relabel":
+			switch v {
+			case "shared":
+				options = append(options, "z")
+			case "private":
+				options = append(options, "Z")
+			default:
+				return Mount{}, fmt.Errorf("invalid value of mount option %q: must be shared or private", field)
+			}
+		case "bind-propagation":
+			options = append(options, v)
+		case "bind-nonrecursive":
+			options = append(options, "bind")
+		case "chown":
+			enabled := true
+			if hasValue {
+				b, err := strconv.ParseBool(v)
+				if err != nil {
+					return Mount{}, fmt.Errorf("invalid value of mount option %q: %s", field, err.Error())
+				}
+				enabled = b
+			}
+			if enabled {
+				options = append(options, "U")
+			}
+		case "tmpfs-size":
+			options = append(options, "size="+v)
+		case "tmpfs-mode":
+			options = append(options, "mode="+v)
+		default:
+			options = append(options, field)
+		}
+	}
+
+	switch mountType {
+	case "bind", "volume", "tmpfs", "image":
+	case "":
+		return Mount{}, fmt.Errorf("missing mount type")
+	default:
+		return Mount{}, fmt.Errorf("unsupported mount type %q: must be one of bind, volume, tmpfs or image", mountType)
+	}
+	for _, key := range keys {
+		if t, ok := mountFlagTypes[key]; ok && t != mountType {
+			return Mount{}, fmt.Errorf("mount option %q is only supported by %s mounts", key, t)
+		}
+	}
+	return newMountFromOptions(mountType, source, destination, options)
+}
+
+// parseVolumeFlag parses the format of the --volume flag, the type is derived from the source and the overlay option
+func parseVolumeFlag(value string) (Mount, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Mount{}, fmt.Errorf("invalid volume %q: must be source:destination[:options]", value)
+	}
+
+	var options []string
+	if len(parts) == 3 && parts[2] != "" {
+		options = strings.Split(parts[2], ",")
+	}
+
+	mountType := "volume"
+	if strings.HasPrefix(parts[0], "/") {
+		mountType = "bind"
+		for _, o := range options {
+			if o == "O" {
+				mountType = "overlay"
+			}
+		}
+	}
+	return newMountFromOptions(mountType, parts[0], parts[1], options)
+}
+
+// newMountFromOptions creates the mount of the type, the options are parsed with the vocabulary of the mount options
+func newMountFromOptions(mountType, source, destination string, options []string) (Mount, error) {
+	if !filepath.IsAbs(destination) {
+		return Mount{}, fmt.Errorf("destination %q must be an absolute path", destination)
+	}
+	switch mountType {
+	case "tmpfs":
+		if source != "" && source != "tmpfs" {
+			return Mount{}, fmt.Errorf("tmpfs mounts do not support a source")
+		}
+	case "bind", "overlay":
+		if !filepath.IsAbs(source) {
+			return Mount{}, fmt.Errorf("source %q of %s mounts must be an absolute path", source, mountType)
+		}
+	default:
+		if source == "" {
+			return Mount{}, fmt.Errorf("missing source of %s mount", mountType)
+		}
+	}
+
+	opts := newAllMountOptions()
+	for _, o := range options {
+		if !opts.parse(o) {
+			return Mount{}, fmt.Errorf("unknown mount option %q", o)
+		}
+		key, _, _ := strings.Cut(o, "=")
+		supported := false
+		for _, s := range mountTypeOptions[mountType] {
+			supported = supported || s == key
+		}
+		if !supported {
+			return Mount{}, fmt.Errorf("mount option %q is not supported by %s mounts", o, mountType)
+		}
+	}
+
+	mount := Mount{
+		Destination: types.StringValue(destination),
+		Options:     types.ListNull(types.StringType),
+	}
+	switch mountType {
+	case "volume":
+		mount.Volume = &MountVolume{
+			Name:     types.StringValue(source),
+			ReadOnly: opts.readOnly,
+			Dev:      opts.dev,
+			Exec:     opts.exec,
+			Suid:     opts.suid,
+			Chown:    opts.chown,
+			IDmap:    opts.idmap,
+		}
+
+	case "bind":
+		mount.Bind = &MountBind{
+			Path:        types.StringValue(source),
+			ReadOnly:    opts.readOnly,
+			Dev:         opts.dev,
+			Exec:        opts.exec,
+			Suid:        opts.suid,
+			Chown:       opts.chown,
+			IDmap:       opts.idmap,
+			Propagation: opts.propagation,
+			Recursive:   opts.recursive,
+			Relabel:     opts.relabel,
+		}
+
+	case "tmpfs":
+		mount.Tmpfs = &MountTmpfs{
+			ReadOnly:  opts.readOnly,
+			Dev:       opts.dev,
+			Exec:      opts.exec,
+			Suid:      opts.suid,
+			Chown:     opts.chown,
+			Size:      opts.size,
+			Mode:      opts.mode,
+			TmpCopyUp: opts.tmpcopyup,
+		}
+
+	case "image":
+		// images are mounted read only by default
+		if opts.readOnly.IsNull() {
+			opts.readOnly = types.BoolValue(true)
+		}
+		mount.Image = &MountImage{
+			Name:     types.StringValue(source),
+			ReadOnly: opts.readOnly,
+		}
+
+	case "overlay":
+		if opts.upperDir.IsNull() != opts.workDir.IsNull() {
+			return Mount{}, fmt.Errorf("overlay options upperdir and workdir must be used together")
+		}
+		mount.Overlay = &MountOverlay{
+			Path:     types.StringValue(source),
+			Chown:    opts.chown,
+			UpperDir: opts.upperDir,
+			WorkDir:  opts.workDir,
+		}
+	}
+	return mount, nil
+}
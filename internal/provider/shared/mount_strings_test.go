@@ -0,0 +1,496 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testMount(destination string) Mount {
+	return Mount{
+		Destination: types.StringValue(destination),
+		Options:     types.ListNull(types.StringType),
+	}
+}
+
+func testVolumeMount(name, destination string, modify func(*MountVolume)) Mount {
+	m := testMount(destination)
+	m.Volume = &MountVolume{
+		Name:     types.StringValue(name),
+		ReadOnly: types.BoolNull(),
+		Chown:    types.BoolValue(false),
+		Suid:     types.BoolNull(),
+		Exec:     types.BoolNull(),
+		Dev:      types.BoolNull(),
+		IDmap:    types.BoolValue(false),
+	}
+	if modify != nil {
+		modify(m.Volume)
+	}
+	return m
+}
+
+func testBindMount(source, destination string, modify func(*MountBind)) Mount {
+	m := testMount(destination)
+	m.Bind = &MountBind{
+		Path:        types.StringValue(source),
+		ReadOnly:    types.BoolNull(),
+		Chown:       types.BoolValue(false),
+		Suid:        types.BoolNull(),
+		Exec:        types.BoolNull(),
+		Dev:         types.BoolNull(),
+		IDmap:       types.BoolValue(false),
+		Propagation: types.StringNull(),
+		Recursive:   types.BoolNull(),
+		Relabel:     types.BoolNull(),
+	}
+	if modify != nil {
+		modify(m.Bind)
+	}
+	return m
+}
+
+func testTmpfsMount(destination string, modify func(*MountTmpfs)) Mount {
+	m := testMount(destination)
+	m.Tmpfs = &MountTmpfs{
+		ReadOnly:  types.BoolNull(),
+		Chown:     types.BoolValue(false),
+		Suid:      types.BoolNull(),
+		Exec:      types.BoolNull(),
+		Dev:       types.BoolNull(),
+		Size:      types.StringNull(),
+		Mode:      types.StringNull(),
+		TmpCopyUp: types.BoolNull(),
+	}
+	if modify != nil {
+		modify(m.Tmpfs)
+	}
+	return m
+}
+
+func testImageMount(name, destination string, readOnly bool) Mount {
+	m := testMount(destination)
+	m.Image = &MountImage{
+		Name:     types.StringValue(name),
+		ReadOnly: types.BoolValue(readOnly),
+	}
+	return m
+}
+
+func testOverlayMount(source, destination string, modify func(*MountOverlay)) Mount {
+	m := testMount(destination)
+	m.Overlay = &MountOverlay{
+		Path:     types.StringValue(source),
+		Chown:    types.BoolValue(false),
+		UpperDir: types.StringNull(),
+		WorkDir:  types.StringNull(),
+	}
+	if modify != nil {
+		modify(m.Overlay)
+	}
+	return m
+}
+
+func TestParseMountString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    string
+		want     Mount
+		wantFail bool
+	}{
+		// --mount flag
+		{
+			desc:  "Mount flag bind with options",
+			value: "type=bind,src=/srv,dst=/data,ro,z",
+			want: testBindMount("/srv", "/data", func(b *MountBind) {
+				b.ReadOnly = types.BoolValue(true)
+				b.Relabel = types.BoolValue(true)
+			}),
+		},
+		{
+			desc:  "Mount flag long keys",
+			value: "type=volume,source=data,target=/data",
+			want:  testVolumeMount("data", "/data", nil),
+		},
+		{
+			desc:  "Mount flag readonly=false",
+			value: "type=volume,src=data,dst=/data,readonly=false",
+			want: testVolumeMount("data", "/data", func(v *MountVolume) {
+				v.ReadOnly = types.BoolValue(false)
+			}),
+		},
+		{
+			desc:  "Mount flag rw=false",
+			value: "type=volume,src=data,dst=/data,rw=false",
+			want: testVolumeMount("data", "/data", func(v *MountVolume) {
+				v.ReadOnly = types.BoolValue(true)
+			}),
+		},
+		{
+			desc:     "Mount flag invalid readonly value",
+			value:    "type=volume,src=data,dst=/data,readonly=maybe",
+			wantFail: true,
+		},
+		{
+			desc:  "Mount flag relabel shared",
+			value: "type=bind,src=/srv,dst=/data,relabel=shared",
+			want: testBindMount("/srv", "/data", func(b *MountBind) {
+				b.Relabel = types.BoolValue(true)
+			}),
+		},
+		{
+			desc:  "Mount flag relabel private",
+			value: "type=bind,src=/srv,dst=/data,relabel=private",
+			want: testBindMount("/srv", "/data", func(b *MountBind) {
+				b.Relabel = types.BoolValue(false)
+			}),
+		},
+		{
+			desc:     "Mount flag invalid relabel value",
+			value:    "type=bind,src=/srv,dst=/data,relabel=yes",
+			wantFail: true,
+		},
+		{
+			desc:  "Mount flag bind propagation and nonrecursive",
+			value: "type=bind,src=/srv,dst=/data,bind-propagation=rslave,bind-nonrecursive",
+			want: testBindMount("/srv", "/data", func(b *MountBind) {
+				b.Propagation = types.StringValue(bindPropagationSlaveRecursive)
+				b.Recursive = types.BoolValue(false)
+			}),
+		},
+		{
+			desc:  "Mount flag chown",
+			value: "type=volume,src=data,dst=/data,chown=true",
+			want: testVolumeMount("data", "/data", func(v *MountVolume) {
+				v.Chown = types.BoolValue(true)
+			}),
+		},
+		{
+			desc:  "Mount flag tmpfs options",
+			value: "type=tmpfs,dst=/run,tmpfs-size=64m,tmpfs-mode=1777,notmpcopyup",
+			want: testTmpfsMount("/run", func(m *MountTmpfs) {
+				m.Size = types.StringValue("64m")
+				m.Mode = types.StringValue("1777")
+				m.TmpCopyUp = types.BoolValue(false)
+			}),
+		},
+		{
+			desc:     "Mount flag tmpfs-size on bind mounts",
+			value:    "type=bind,src=/srv,dst=/data,tmpfs-size=64m",
+			wantFail: true,
+		},
+		{
+			desc:     "Mount flag tmpfs-mode on volume mounts",
+			value:    "type=volume,src=data,dst=/data,tmpfs-mode=1777",
+			wantFail: true,
+		},
+		{
+			desc:     "Mount flag relabel on volume mounts",
+			value:    "type=volume,src=data,dst=/data,relabel=shared",
+			wantFail: true,
+		},
+		{
+			desc:  "Mount flag image is read only by default",
+			value: "type=image,src=alpine,dst=/image",
+			want:  testImageMount("alpine", "/image", true),
+		},
+		{
+			desc:  "Mount flag image read write",
+			value: "type=image,src=alpine,dst=/image,rw=true",
+			want:  testImageMount("alpine", "/image", false),
+		},
+		{
+			desc:     "Mount flag unsupported type",
+			value:    "type=devpts,dst=/dev/pts",
+			wantFail: true,
+		},
+		{
+			desc:     "Mount flag unknown option",
+			value:    "type=volume,src=data,dst=/data,foo=bar",
+			wantFail: true,
+		},
+		{
+			desc:     "Mount flag option unsupported by type",
+			value:    "type=image,src=alpine,dst=/image,nodev",
+			wantFail: true,
+		},
+		{
+			desc:     "Mount flag relative destination",
+			value:    "type=volume,src=data,dst=data",
+			wantFail: true,
+		},
+		// --volume flag
+		{
+			desc:  "Volume flag named volume",
+			value: "data:/data:ro,U",
+			want: testVolumeMount("data", "/data", func(v *MountVolume) {
+				v.ReadOnly = types.BoolValue(true)
+				v.Chown = types.BoolValue(true)
+			}),
+		},
+		{
+			desc:  "Volume flag bind mount",
+			value: "/srv:/data:Z,rshared",
+			want: testBindMount("/srv", "/data", func(b *MountBind) {
+				b.Relabel = types.BoolValue(false)
+				b.Propagation = types.StringValue(bindPropagationSharedRecursive)
+			}),
+		},
+		{
+			desc:  "Volume flag without options",
+			value: "/srv:/data",
+			want:  testBindMount("/srv", "/data", nil),
+		},
+		{
+			desc:  "Volume flag overlay",
+			value: "/srv:/data:O",
+			want:  testOverlayMount("/srv", "/data", nil),
+		},
+		{
+			desc:  "Volume flag overlay with upperdir and workdir",
+			value: "/srv:/data:O,upperdir=/tmp/upper,workdir=/tmp/work",
+			want: testOverlayMount("/srv", "/data", func(o *MountOverlay) {
+				o.UpperDir = types.StringValue("/tmp/upper")
+				o.WorkDir = types.StringValue("/tmp/work")
+			}),
+		},
+		{
+			desc:     "Volume flag overlay with upperdir only",
+			value:    "/srv:/data:O,upperdir=/tmp/upper",
+			wantFail: true,
+		},
+		{
+			desc:     "Volume flag overlay with bind options",
+			value:    "/srv:/data:O,ro",
+			wantFail: true,
+		},
+		{
+			desc:     "Volume flag tmpfs options on volumes",
+			value:    "data:/data:size=64m",
+			wantFail: true,
+		},
+		{
+			desc:     "Volume flag unknown option",
+			value:    "data:/data:foo",
+			wantFail: true,
+		},
+		{
+			desc:     "Volume flag missing destination",
+			value:    "data",
+			wantFail: true,
+		},
+		{
+			desc:     "Volume flag too many parts",
+			value:    "data:/data:ro:z",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := ParseMountString(test.value)
+			if test.wantFail {
+				if err == nil {
+					t.Errorf("%s: expected error for %q", test.desc, test.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", test.desc, err.Error())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %+v, want %+v", test.desc, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseMountFlag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    string
+		wantFail bool
+	}{
+		{
+			desc:  "Type is not required as first key",
+			value: "src=data,dst=/data,type=volume",
+		},
+		{
+			desc:     "Missing type",
+			value:    "src=data,dst=/data",
+			wantFail: true,
+		},
+		{
+			desc:     "Invalid chown value",
+			value:    "type=volume,src=data,dst=/data,chown=maybe",
+			wantFail: true,
+		},
+		{
+			desc:     "Missing volume source",
+			value:    "type=volume,dst=/data",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := parseMountFlag(test.value)
+			if (err != nil) != test.wantFail {
+				t.Errorf("%s: got error %v, want failure %t", test.desc, err, test.wantFail)
+			}
+		})
+	}
+}
+
+func TestParseVolumeFlag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    string
+		wantType string
+		wantFail bool
+	}{
+		{
+			desc:     "Relative source is a named volume",
+			value:    "data:/data",
+			wantType: "volume",
+		},
+		{
+			desc:     "Absolute source is a bind mount",
+			value:    "/srv:/data",
+			wantType: "bind",
+		},
+		{
+			desc:     "Absolute source with O is an overlay",
+			value:    "/srv:/data:U,O",
+			wantType: "overlay",
+		},
+		{
+			desc:     "Named volume with O is not an overlay",
+			value:    "data:/data:O",
+			wantFail: true,
+		},
+		{
+			desc:     "Empty options",
+			value:    "data:/data:",
+			wantType: "volume",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := parseVolumeFlag(test.value)
+			if test.wantFail {
+				if err == nil {
+					t.Errorf("%s: expected error for %q", test.desc, test.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", test.desc, err.Error())
+			}
+			gotType := ""
+			switch {
+			case got.Volume != nil:
+				gotType = "volume"
+			case got.Bind != nil:
+				gotType = "bind"
+			case got.Overlay != nil:
+				gotType = "overlay"
+			}
+			if gotType != test.wantType {
+				t.Errorf("%s: got type %q, want %q", test.desc, gotType, test.wantType)
+			}
+		})
+	}
+}
+
+func TestNewMountFromOptions(t *testing.T) {
+	tests := []struct {
+		desc        string
+		mountType   string
+		source      string
+		destination string
+		options     []string
+		wantFail    bool
+	}{
+		{
+			desc:        "Tmpfs without source",
+			mountType:   "tmpfs",
+			destination: "/run",
+		},
+		{
+			desc:        "Tmpfs with tmpfs source",
+			mountType:   "tmpfs",
+			source:      "tmpfs",
+			destination: "/run",
+		},
+		{
+			desc:        "Tmpfs with source",
+			mountType:   "tmpfs",
+			source:      "/srv",
+			destination: "/run",
+			wantFail:    true,
+		},
+		{
+			desc:        "Bind with relative source",
+			mountType:   "bind",
+			source:      "srv",
+			destination: "/data",
+			wantFail:    true,
+		},
+		{
+			desc:        "Overlay with relative source",
+			mountType:   "overlay",
+			source:      "srv",
+			destination: "/data",
+			options:     []string{"O"},
+			wantFail:    true,
+		},
+		{
+			desc:        "Image without source",
+			mountType:   "image",
+			destination: "/image",
+			wantFail:    true,
+		},
+		{
+			desc:        "Relative destination",
+			mountType:   "volume",
+			source:      "data",
+			destination: "data",
+			wantFail:    true,
+		},
+		{
+			desc:        "Tmpfs options on bind mounts",
+			mountType:   "bind",
+			source:      "/srv",
+			destination: "/data",
+			options:     []string{"mode=1777"},
+			wantFail:    true,
+		},
+		{
+			desc:        "Overlay directories on bind mounts",
+			mountType:   "bind",
+			source:      "/srv",
+			destination: "/data",
+			options:     []string{"upperdir=/tmp/upper", "workdir=/tmp/work"},
+			wantFail:    true,
+		},
+		{
+			desc:        "Unknown option",
+			mountType:   "tmpfs",
+			destination: "/run",
+			options:     []string{"nr_inodes=100"},
+			wantFail:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := newMountFromOptions(test.mountType, test.source, test.destination, test.options)
+			if (err != nil) != test.wantFail {
+				t.Errorf("%s: got error %v, want failure %t", test.desc, err, test.wantFail)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// SliceStringToListType maps a native golang slice to a terraform list type,
+// empty slices are mapped to a null list
+func SliceStringToListType(s []string, diags *diag.Diagnostics) types.List {
+	if len(s) == 0 {
+		return types.ListNull(types.StringType)
+	}
+
+	elems := make([]attr.Value, 0, len(s))
+	for _, v := range s {
+		elems = append(elems, types.StringValue(v))
+	}
+	v, d := types.ListValue(types.StringType, elems)
+	diags.Append(d...)
+	return v
+}
+
+// StringInSlice checks if the string is part of the slice
+func StringInSlice(s string, slice []string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
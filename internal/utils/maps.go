@@ -47,3 +47,16 @@ func MapStringValueToIntType(m map[string]string, key string, diags *diag.Diagno
 	}
 	return types.Int64Null()
 }
+
+// MapStringValueToBoolType extracts a terraform bool value from a map with string
+func MapStringValueToBoolType(m map[string]string, key string, diags *diag.Diagnostics) types.Bool {
+	val, exist := m[key]
+	if !exist {
+		return types.BoolNull()
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		diags.AddError("Cannot convert string to boolean", fmt.Sprintf("Received value %s for key %s is not convertable: %s ", val, key, err.Error()))
+	}
+	return types.BoolValue(b)
+}
@@ -1,19 +1,24 @@
 package validators
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	regexNetworkInterface = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	// podman name rule limited to the kernel interface name length (IFNAMSIZ - 1)
+	regexNetworkInterface = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,14}$`)
 )
 
+// MatchNetworkInterfaceName validates the name of a host network interface
 func MatchNetworkInterfaceName() validator.String {
 	return stringvalidator.RegexMatches(regexNetworkInterface, "")
 }
@@ -48,3 +53,239 @@ func IsIpAdress() validator.String {
 		},
 	}
 }
+
+// IsIPv4Address validates the value is an ipv4 address
+func IsIPv4Address() validator.String {
+	return &genericStringValidator{
+		description: "value must be an IPv4 address",
+		validate: func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+			if ip := net.ParseIP(req.ConfigValue.ValueString()); ip == nil || ip.To4() == nil {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Failed to parse IPv4 address",
+					fmt.Sprintf("invalid value: %s", req.ConfigValue.String()),
+				)
+			}
+		},
+	}
+}
+
+// IsIPv6Address validates the value is an ipv6 address
+func IsIPv6Address() validator.String {
+	return &genericStringValidator{
+		description: "value must be an IPv6 address",
+		validate: func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+			if ip := net.ParseIP(req.ConfigValue.ValueString()); ip == nil || ip.To4() != nil {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Failed to parse IPv6 address",
+					fmt.Sprintf("invalid value: %s", req.ConfigValue.String()),
+				)
+			}
+		},
+	}
+}
+
+// IsMACAddress validates the value is an ethernet mac address
+func IsMACAddress() validator.String {
+	return &genericStringValidator{
+		description: "value must be a MAC address",
+		validate: func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+			mac, err := net.ParseMAC(req.ConfigValue.ValueString())
+			if err == nil && len(mac) != 6 {
+				err = fmt.Errorf("not an ethernet address")
+			}
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Failed to parse MAC address",
+					fmt.Sprintf("invalid value: %s, error: %s", req.ConfigValue.String(), err.Error()),
+				)
+			}
+		},
+	}
+}
+
+// SubnetLeaseRange validates the lease range of a subnet object,
+// the range must not be empty, must be within the subnet and must not include the gateway.
+func SubnetLeaseRange() validator.Object {
+	return &genericObjectValidator{
+		description: "lease range must be within the subnet and must not include the gateway",
+		validate: func(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+			attrs := req.ConfigValue.Attributes()
+
+			subnet, ok := knownStringAttribute(attrs, "subnet")
+			if !ok {
+				return
+			}
+			_, ipNet, err := net.ParseCIDR(subnet)
+			if err != nil {
+				// reported by the subnet attribute validator
+				return
+			}
+
+			leaseRange, ok := attrs["lease_range"].(types.Object)
+			if !ok || leaseRange.IsNull() || leaseRange.IsUnknown() {
+				return
+			}
+			rangeAttrs := leaseRange.Attributes()
+			rangePath := req.Path.AtName("lease_range")
+
+			if rangeAttrs["start_ip"].IsNull() && rangeAttrs["end_ip"].IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					rangePath,
+					"Empty lease range",
+					"At least one of start_ip or end_ip must be set.",
+				)
+				return
+			}
+
+			// the range defaults to the boundaries of the subnet
+			start := ipNet.IP
+			end := lastIPInNet(ipNet)
+
+			for _, name := range []string{"start_ip", "end_ip"} {
+				val, ok := knownStringAttribute(rangeAttrs, name)
+				if !ok {
+					continue
+				}
+				ip := net.ParseIP(val)
+				if ip == nil {
+					// reported by the ip attribute validator
+					return
+				}
+				if !ipNet.Contains(ip) {
+					resp.Diagnostics.AddAttributeError(
+						rangePath.AtName(name),
+						"Lease range outside of subnet",
+						fmt.Sprintf("The IP %s is not within the subnet %s.", val, subnet),
+					)
+					continue
+				}
+				if name == "start_ip" {
+					start = ip
+				} else {
+					end = ip
+				}
+			}
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if compareIP(start, end) > 0 {
+				resp.Diagnostics.AddAttributeError(
+					rangePath,
+					"Invalid lease range",
+					fmt.Sprintf("The start IP %s must not be greater than the end IP %s.", start, end),
+				)
+				return
+			}
+
+			gateway, ok := knownStringAttribute(attrs, "gateway")
+			if !ok {
+				return
+			}
+			if gwIP := net.ParseIP(gateway); gwIP != nil && compareIP(start, gwIP) <= 0 && compareIP(gwIP, end) <= 0 {
+				resp.Diagnostics.AddAttributeError(
+					rangePath,
+					"Lease range includes gateway",
+					fmt.Sprintf("The gateway %s must not be within the lease range %s - %s.", gateway, start, end),
+				)
+			}
+		},
+	}
+}
+
+// SubnetGateway validates the gateway of a subnet object is within the subnet.
+func SubnetGateway() validator.Object {
+	return &genericObjectValidator{
+		description: "gateway must be within the subnet",
+		validate: func(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+			attrs := req.ConfigValue.Attributes()
+
+			subnet, ok := knownStringAttribute(attrs, "subnet")
+			if !ok {
+				return
+			}
+			gateway, ok := knownStringAttribute(attrs, "gateway")
+			if !ok {
+				return
+			}
+			_, ipNet, err := net.ParseCIDR(subnet)
+			gwIP := net.ParseIP(gateway)
+			if err != nil || gwIP == nil {
+				// reported by the attribute validators
+				return
+			}
+
+			if !ipNet.Contains(gwIP) {
+				resp.Diagnostics.AddAttributeError(
+					req.Path.AtName("gateway"),
+					"Gateway outside of subnet",
+					fmt.Sprintf("The gateway %s is not within the subnet %s.", gateway, subnet),
+				)
+			}
+		},
+	}
+}
+
+// SubnetsNotOverlapping validates the subnets of a set of subnet objects do not overlap each other.
+func SubnetsNotOverlapping() validator.Set {
+	return &genericSetValidator{
+		description: "subnets must not overlap",
+		validate: func(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+			var nets []*net.IPNet
+			for _, elem := range req.ConfigValue.Elements() {
+				obj, ok := elem.(types.Object)
+				if !ok || obj.IsNull() || obj.IsUnknown() {
+					continue
+				}
+				subnet, ok := knownStringAttribute(obj.Attributes(), "subnet")
+				if !ok {
+					continue
+				}
+				_, ipNet, err := net.ParseCIDR(subnet)
+				if err != nil {
+					// reported by the subnet attribute validator
+					continue
+				}
+
+				for _, other := range nets {
+					if other.Contains(ipNet.IP) || ipNet.Contains(other.IP) {
+						resp.Diagnostics.AddAttributeError(
+							req.Path.AtSetValue(obj).AtName("subnet"),
+							"Overlapping subnets",
+							fmt.Sprintf("The subnet %s overlaps with the subnet %s.", ipNet, other),
+						)
+						break
+					}
+				}
+				nets = append(nets, ipNet)
+			}
+		},
+	}
+}
+
+// knownStringAttribute returns the value of a known and not null string attribute
+func knownStringAttribute(attrs map[string]attr.Value, name string) (string, bool) {
+	val, ok := attrs[name].(types.String)
+	if !ok || val.IsNull() || val.IsUnknown() {
+		return "", false
+	}
+	return val.ValueString(), true
+}
+
+// compareIP compares two ip addresses of the same family
+func compareIP(a, b net.IP) int {
+	return bytes.Compare(a.To16(), b.To16())
+}
+
+// lastIPInNet returns the last ip address of the network
+func lastIPInNet(n *net.IPNet) net.IP {
+	ip := make(net.IP, len(n.IP))
+	for i := range n.IP {
+		ip[i] = n.IP[i] | ^n.Mask[i]
+	}
+	return ip
+}
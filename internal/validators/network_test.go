@@ -0,0 +1,351 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	testLeaseRangeAttrTypes = map[string]attr.Type{
+		"start_ip": types.StringType,
+		"end_ip":   types.StringType,
+	}
+	testSubnetAttrTypes = map[string]attr.Type{
+		"subnet":      types.StringType,
+		"gateway":     types.StringType,
+		"lease_range": types.ObjectType{AttrTypes: testLeaseRangeAttrTypes},
+	}
+)
+
+func TestStringValidator_NetworkInterfaceName(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchNetworkInterfaceName(),
+		},
+		{
+			desc: "Interface name is valid",
+			values: testStringToVals(
+				"podman0",
+				"br-payments",
+				"eth0.100",
+				"enp0s31f6",
+				"veth_1",
+				"abcdefghijklmno",
+			),
+			validator: MatchNetworkInterfaceName(),
+		},
+		{
+			desc: "Interface name should fail",
+			values: testStringToVals(
+				"",
+				"-br0",
+				"br 0",
+				"br/0",
+				"abcdefghijklmnop",
+			),
+			wantFail:  true,
+			validator: MatchNetworkInterfaceName(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_IPAddressFamily(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: IsIPv4Address(),
+		},
+		{
+			desc:      "IPv4 address is valid",
+			values:    testStringToVals("192.0.2.10", "10.88.0.1"),
+			validator: IsIPv4Address(),
+		},
+		{
+			desc:      "IPv4 address should fail",
+			values:    testStringToVals("", "2001:db8::10", "192.0.2.0/24", "192.0.2.256"),
+			wantFail:  true,
+			validator: IsIPv4Address(),
+		},
+		{
+			desc:      "IPv6 address is valid",
+			values:    testStringToVals("2001:db8::10", "fd00::1"),
+			validator: IsIPv6Address(),
+		},
+		{
+			desc:      "IPv6 address should fail",
+			values:    testStringToVals("", "192.0.2.10", "2001:db8::/64"),
+			wantFail:  true,
+			validator: IsIPv6Address(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_MACAddress(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: IsMACAddress(),
+		},
+		{
+			desc:      "MAC address is valid",
+			values:    testStringToVals("92:d0:c6:0a:29:33", "92-D0-C6-0A-29-33"),
+			validator: IsMACAddress(),
+		},
+		{
+			desc:      "MAC address should fail",
+			values:    testStringToVals("", "92:d0:c6:0a:29", "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01", "zz:d0:c6:0a:29:33"),
+			wantFail:  true,
+			validator: IsMACAddress(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func testSubnetObject(subnet, gateway, start, end types.String) types.Object {
+	leaseRange := types.ObjectNull(testLeaseRangeAttrTypes)
+	if !start.IsNull() || !end.IsNull() {
+		leaseRange = types.ObjectValueMust(testLeaseRangeAttrTypes, map[string]attr.Value{
+			"start_ip": start,
+			"end_ip":   end,
+		})
+	}
+	return types.ObjectValueMust(testSubnetAttrTypes, map[string]attr.Value{
+		"subnet":      subnet,
+		"gateway":     gateway,
+		"lease_range": leaseRange,
+	})
+}
+
+func TestObjectValidator_SubnetLeaseRange(t *testing.T) {
+	null := types.StringNull()
+	str := types.StringValue
+
+	tests := []struct {
+		desc     string
+		value    types.Object
+		wantFail bool
+	}{
+		{
+			desc:  "Null is valid",
+			value: types.ObjectNull(testSubnetAttrTypes),
+		},
+		{
+			desc:  "Unknown is valid",
+			value: types.ObjectUnknown(testSubnetAttrTypes),
+		},
+		{
+			desc:  "No lease range is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), str("192.0.2.1"), null, null),
+		},
+		{
+			desc:  "Lease range within subnet is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), str("192.0.2.1"), str("192.0.2.100"), str("192.0.2.200")),
+		},
+		{
+			desc:  "Lease range start only is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), str("192.0.2.1"), str("192.0.2.100"), null),
+		},
+		{
+			desc:  "IPv6 lease range is valid",
+			value: testSubnetObject(str("2001:db8::/64"), str("2001:db8::1"), str("2001:db8::100"), str("2001:db8::200")),
+		},
+		{
+			desc:  "Unknown gateway is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), types.StringUnknown(), str("192.0.2.100"), str("192.0.2.200")),
+		},
+		{
+			desc: "Empty lease range should fail",
+			value: types.ObjectValueMust(testSubnetAttrTypes, map[string]attr.Value{
+				"subnet":  str("192.0.2.0/24"),
+				"gateway": null,
+				"lease_range": types.ObjectValueMust(testLeaseRangeAttrTypes, map[string]attr.Value{
+					"start_ip": null,
+					"end_ip":   null,
+				}),
+			}),
+			wantFail: true,
+		},
+		{
+			desc:     "Start outside subnet should fail",
+			value:    testSubnetObject(str("192.0.2.0/24"), null, str("198.51.100.10"), str("192.0.2.200")),
+			wantFail: true,
+		},
+		{
+			desc:     "End outside subnet should fail",
+			value:    testSubnetObject(str("192.0.2.0/24"), null, str("192.0.2.10"), str("192.0.3.1")),
+			wantFail: true,
+		},
+		{
+			desc:     "Start greater than end should fail",
+			value:    testSubnetObject(str("192.0.2.0/24"), null, str("192.0.2.200"), str("192.0.2.100")),
+			wantFail: true,
+		},
+		{
+			desc:     "Gateway within range should fail",
+			value:    testSubnetObject(str("192.0.2.0/24"), str("192.0.2.150"), str("192.0.2.100"), str("192.0.2.200")),
+			wantFail: true,
+		},
+		{
+			desc:     "Gateway within default end of range should fail",
+			value:    testSubnetObject(str("192.0.2.0/24"), str("192.0.2.254"), str("192.0.2.100"), null),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := validator.ObjectRequest{
+				Path:        path.Root("subnets"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.ObjectResponse{}
+
+			SubnetLeaseRange().ValidateObject(context.TODO(), req, resp)
+			if test.wantFail != resp.Diagnostics.HasError() {
+				t.Errorf("%s: err: %v", test.desc, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestObjectValidator_SubnetGateway(t *testing.T) {
+	null := types.StringNull()
+	str := types.StringValue
+
+	tests := []struct {
+		desc     string
+		value    types.Object
+		wantFail bool
+	}{
+		{
+			desc:  "Null is valid",
+			value: types.ObjectNull(testSubnetAttrTypes),
+		},
+		{
+			desc:  "No gateway is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), null, null, null),
+		},
+		{
+			desc:  "Unknown gateway is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), types.StringUnknown(), null, null),
+		},
+		{
+			desc:  "Gateway within subnet is valid",
+			value: testSubnetObject(str("192.0.2.0/24"), str("192.0.2.1"), null, null),
+		},
+		{
+			desc:  "IPv6 gateway within subnet is valid",
+			value: testSubnetObject(str("2001:db8::/64"), str("2001:db8::1"), null, null),
+		},
+		{
+			desc:     "Gateway outside subnet should fail",
+			value:    testSubnetObject(str("192.0.2.0/24"), str("198.51.100.1"), null, null),
+			wantFail: true,
+		},
+		{
+			desc:     "IPv4 gateway in IPv6 subnet should fail",
+			value:    testSubnetObject(str("2001:db8::/64"), str("192.0.2.1"), null, null),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := validator.ObjectRequest{
+				Path:        path.Root("subnets"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.ObjectResponse{}
+
+			SubnetGateway().ValidateObject(context.TODO(), req, resp)
+			if test.wantFail != resp.Diagnostics.HasError() {
+				t.Errorf("%s: err: %v", test.desc, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSetValidator_SubnetsNotOverlapping(t *testing.T) {
+	null := types.StringNull()
+	str := types.StringValue
+	subnets := func(cidrs ...string) types.Set {
+		elems := make([]attr.Value, 0, len(cidrs))
+		for _, c := range cidrs {
+			elems = append(elems, testSubnetObject(str(c), null, null, null))
+		}
+		return types.SetValueMust(types.ObjectType{AttrTypes: testSubnetAttrTypes}, elems)
+	}
+
+	tests := []struct {
+		desc     string
+		value    types.Set
+		wantFail bool
+	}{
+		{
+			desc:  "Null is valid",
+			value: types.SetNull(types.ObjectType{AttrTypes: testSubnetAttrTypes}),
+		},
+		{
+			desc:  "Single subnet is valid",
+			value: subnets("192.0.2.0/24"),
+		},
+		{
+			desc:  "Distinct subnets are valid",
+			value: subnets("192.0.2.0/24", "198.51.100.0/24", "2001:db8::/64"),
+		},
+		{
+			desc:  "Adjacent subnets are valid",
+			value: subnets("192.0.2.0/25", "192.0.2.128/25"),
+		},
+		{
+			desc:     "Contained subnet should fail",
+			value:    subnets("192.0.2.0/24", "192.0.2.64/26"),
+			wantFail: true,
+		},
+		{
+			desc:     "Containing subnet should fail",
+			value:    subnets("192.0.2.64/26", "192.0.0.0/16"),
+			wantFail: true,
+		},
+		{
+			desc:     "Overlapping IPv6 subnets should fail",
+			value:    subnets("2001:db8::/64", "2001:db8::/48"),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := validator.SetRequest{
+				Path:        path.Root("subnets"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.SetResponse{}
+
+			SubnetsNotOverlapping().ValidateSet(context.TODO(), req, resp)
+			if test.wantFail != resp.Diagnostics.HasError() {
+				t.Errorf("%s: err: %v", test.desc, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/go-units"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UniqueHostPorts validates a set of port mapping objects does not publish a host port and protocol twice,
+// port ranges are expanded and the unspecified host ip overlaps with all other host ips.
+func UniqueHostPorts() validator.Set {
+	return &genericSetValidator{
+		description: "host port and protocol must be unique",
+		validate: func(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+			type hostPort struct {
+				ip       string
+				port     int64
+				protocol string
+			}
+			var used []hostPort
+
+			for _, elem := range req.ConfigValue.Elements() {
+				obj, ok := elem.(types.Object)
+				if !ok || obj.IsNull() || obj.IsUnknown() {
+					continue
+				}
+				attrs := obj.Attributes()
+
+				port, ok := attrs["host_port"].(types.Int64)
+				if !ok || port.IsNull() || port.IsUnknown() {
+					// random host ports are assigned by podman
+					continue
+				}
+				portRange := int64(1)
+				if r, ok := attrs["range"].(types.Int64); ok && !r.IsNull() {
+					if r.IsUnknown() {
+						continue
+					}
+					portRange = r.ValueInt64()
+				}
+				protocol := "tcp"
+				if p, ok := attrs["protocol"].(types.String); ok && !p.IsNull() {
+					if p.IsUnknown() {
+						continue
+					}
+					protocol = p.ValueString()
+				}
+				ip := ""
+				if i, ok := attrs["host_ip"].(types.String); ok && !i.IsNull() {
+					if i.IsUnknown() {
+						continue
+					}
+					ip = i.ValueString()
+				}
+
+				for p := port.ValueInt64(); p < port.ValueInt64()+portRange; p++ {
+					current := hostPort{ip: ip, port: p, protocol: protocol}
+					for _, u := range used {
+						if u.port == current.port && u.protocol == current.protocol && hostIPOverlaps(u.ip, current.ip) {
+							resp.Diagnostics.AddAttributeError(
+								req.Path.AtSetValue(obj),
+								"Duplicate host port",
+								fmt.Sprintf("The host port %d/%s is published more than once.", current.port, current.protocol),
+							)
+							return
+						}
+					}
+					used = append(used, current)
+				}
+			}
+		},
+	}
+}
+
+// hostIPOverlaps checks if two host ips bind the same address, an empty or unspecified ip binds all addresses
+func hostIPOverlaps(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil || ipA.IsUnspecified() || ipB.IsUnspecified() {
+		return true
+	}
+	return ipA.Equal(ipB)
+}
+
+// IsByteSize validates the value is a size in bytes with an optional unit, e.g. 512m
+func IsByteSize() validator.String {
+	return &genericStringValidator{
+		description: "value must be a size in bytes with an optional unit (b, k, m, g)",
+		validate: func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+			if _, err := units.RAMInBytes(req.ConfigValue.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Failed to parse size",
+					fmt.Sprintf("invalid value: %s, error: %s", req.ConfigValue.String(), err.Error()),
+				)
+			}
+		},
+	}
+}
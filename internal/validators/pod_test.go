@@ -0,0 +1,154 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	testPortAttrTypes = map[string]attr.Type{
+		"host_ip":        types.StringType,
+		"host_port":      types.Int64Type,
+		"container_port": types.Int64Type,
+		"protocol":       types.StringType,
+		"range":          types.Int64Type,
+	}
+)
+
+func testPortObject(hostIP types.String, hostPort types.Int64, protocol types.String, portRange types.Int64) types.Object {
+	return types.ObjectValueMust(testPortAttrTypes, map[string]attr.Value{
+		"host_ip":        hostIP,
+		"host_port":      hostPort,
+		"container_port": types.Int64Value(80),
+		"protocol":       protocol,
+		"range":          portRange,
+	})
+}
+
+func TestSetValidator_UniqueHostPorts(t *testing.T) {
+	null := types.StringNull()
+	str := types.StringValue
+	port := types.Int64Value
+	ports := func(objs ...types.Object) types.Set {
+		elems := make([]attr.Value, 0, len(objs))
+		for _, o := range objs {
+			elems = append(elems, o)
+		}
+		return types.SetValueMust(types.ObjectType{AttrTypes: testPortAttrTypes}, elems)
+	}
+
+	tests := []struct {
+		desc     string
+		value    types.Set
+		wantFail bool
+	}{
+		{
+			desc:  "Null is valid",
+			value: types.SetNull(types.ObjectType{AttrTypes: testPortAttrTypes}),
+		},
+		{
+			desc: "Distinct host ports are valid",
+			value: ports(
+				testPortObject(null, port(8080), null, types.Int64Null()),
+				testPortObject(null, port(8081), null, types.Int64Null()),
+			),
+		},
+		{
+			desc: "Same host port with different protocols is valid",
+			value: ports(
+				testPortObject(null, port(8080), null, types.Int64Null()),
+				testPortObject(null, port(8080), str("udp"), types.Int64Null()),
+			),
+		},
+		{
+			desc: "Same host port on different host ips is valid",
+			value: ports(
+				testPortObject(str("127.0.0.1"), port(8080), null, types.Int64Null()),
+				testPortObject(str("192.0.2.1"), port(8080), null, types.Int64Null()),
+			),
+		},
+		{
+			desc: "Random host ports are valid",
+			value: ports(
+				testPortObject(null, types.Int64Null(), null, types.Int64Null()),
+				testPortObject(str("127.0.0.1"), types.Int64Null(), null, types.Int64Null()),
+			),
+		},
+		{
+			desc: "Adjacent port ranges are valid",
+			value: ports(
+				testPortObject(null, port(8080), null, port(10)),
+				testPortObject(null, port(8090), null, port(10)),
+			),
+		},
+		{
+			desc: "Duplicate host port should fail",
+			value: ports(
+				testPortObject(null, port(8080), null, types.Int64Null()),
+				testPortObject(null, port(8080), str("tcp"), types.Int64Null()),
+			),
+			wantFail: true,
+		},
+		{
+			desc: "Duplicate host port on all host ips should fail",
+			value: ports(
+				testPortObject(str("0.0.0.0"), port(8080), null, types.Int64Null()),
+				testPortObject(str("127.0.0.1"), port(8080), null, types.Int64Null()),
+			),
+			wantFail: true,
+		},
+		{
+			desc: "Overlapping port ranges should fail",
+			value: ports(
+				testPortObject(null, port(8080), str("udp"), port(10)),
+				testPortObject(null, port(8085), str("udp"), types.Int64Null()),
+			),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := validator.SetRequest{
+				Path:        path.Root("ports"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.SetResponse{}
+
+			UniqueHostPorts().ValidateSet(context.TODO(), req, resp)
+			if test.wantFail != resp.Diagnostics.HasError() {
+				t.Errorf("%s: err: %v", test.desc, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestStringValidator_ByteSize(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: IsByteSize(),
+		},
+		{
+			desc:      "Size is valid",
+			values:    testStringToVals("1024", "512m", "512M", "1g", "1.5GB", "64kb"),
+			validator: IsByteSize(),
+		},
+		{
+			desc:      "Size should fail",
+			values:    testStringToVals("", "-1", "m", "512x", "1 2"),
+			wantFail:  true,
+			validator: IsByteSize(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
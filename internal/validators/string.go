@@ -11,6 +11,14 @@ var (
 	regexName     = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
 	regexOctal    = regexp.MustCompile(`^[0-7]{3,4}$`)
 	regexTmpfSize = regexp.MustCompile(`^(\d+[kmg]?|\d{1,3}%)$`)
+	regexNSMode   = regexp.MustCompile(`^(private|host|ns:/.+)$`)
+	regexCPUSet   = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+	regexIDMap    = regexp.MustCompile(`^\d+:\d+:[1-9]\d*$`)
+	regexAbsPath  = regexp.MustCompile(`^/[^:]*$`)
+	regexDevPerm  = regexp.MustCompile(`^(r?w?m?|r?m?w?|w?r?m?|w?m?r?|m?r?w?|m?w?r?)$`)
+	regexSize     = regexp.MustCompile(`^\d+(\.\d+)?([kKmMgGtTpP]?[bB]?)$`)
+	regexMountOpt = regexp.MustCompile(`^[^,\s]+$`)
+	regexDevice   = regexp.MustCompile(`^(/[^:]*|[a-z0-9]+([.-][a-z0-9]+)*/[a-zA-Z0-9]+([._-][a-zA-Z0-9]+)*=[a-zA-Z0-9]+([._:-][a-zA-Z0-9]+)*)$`)
 )
 
 // MatchName validates given name to be compatible with podman
@@ -27,3 +35,46 @@ func MatchOctal() validator.String {
 func MatchTmpfSize() validator.String {
 	return stringvalidator.RegexMatches(regexTmpfSize, "")
 }
+
+// MatchNamespaceMode validates the namespace mode is private, host or a namespace path
+func MatchNamespaceMode() validator.String {
+	return stringvalidator.RegexMatches(regexNSMode, "must be private, host or ns:<path>")
+}
+
+// MatchCPUSet validates a list of cpus or memory nodes, e.g. 0-3,5
+func MatchCPUSet() validator.String {
+	return stringvalidator.RegexMatches(regexCPUSet, "must be a list or range of numbers, e.g. 0-3,5")
+}
+
+// MatchIDMap validates an id mapping of a user namespace, e.g. 0:100000:65536
+func MatchIDMap() validator.String {
+	return stringvalidator.RegexMatches(regexIDMap, "must be container_id:host_id:size")
+}
+
+// MatchAbsolutePath validates an absolute path
+func MatchAbsolutePath() validator.String {
+	return stringvalidator.RegexMatches(regexAbsPath, "must be an absolute path")
+}
+
+// MatchDevicePermissions validates the cgroup permissions of a device, any combination of r, w and m
+func MatchDevicePermissions() validator.String {
+	return stringvalidator.All(
+		stringvalidator.LengthAtLeast(1),
+		stringvalidator.RegexMatches(regexDevPerm, "must be a combination of r, w and m"),
+	)
+}
+
+// MatchDevice validates the absolute path of a device or a fully qualified CDI device name, e.g. vendor.com/class=name
+func MatchDevice() validator.String {
+	return stringvalidator.RegexMatches(regexDevice, "must be an absolute path or a CDI device name")
+}
+
+// MatchSize validates a size in bytes with an optional unit, e.g. 512m or 10GB
+func MatchSize() validator.String {
+	return stringvalidator.RegexMatches(regexSize, "must be a size with an optional unit, e.g. 512m or 10GB")
+}
+
+// MatchMountOption validates a single mount option, e.g. noatime or addr=10.0.0.1
+func MatchMountOption() validator.String {
+	return stringvalidator.RegexMatches(regexMountOpt, "must be a single mount option without commas or spaces")
+}
@@ -97,3 +97,214 @@ func TestStringValidator_TmpfSize(t *testing.T) {
 
 	testValidatorStringExecute(t, tests)
 }
+
+func TestStringValidator_NamespaceMode(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchNamespaceMode(),
+		},
+		{
+			desc: "Namespace mode is valid",
+			values: testStringToVals(
+				"private",
+				"host",
+				"ns:/proc/1/ns/pid",
+			),
+			validator: MatchNamespaceMode(),
+		},
+		{
+			desc: "Namespace mode should fail",
+			values: testStringToVals(
+				"",
+				"pod",
+				"ns:",
+				"ns:relative",
+				"container:abc",
+				"Host",
+			),
+			wantFail:  true,
+			validator: MatchNamespaceMode(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_CPUSet(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchCPUSet(),
+		},
+		{
+			desc:      "CPU set is valid",
+			values:    testStringToVals("0", "0-3", "0,2", "0-3,5,7-8"),
+			validator: MatchCPUSet(),
+		},
+		{
+			desc:      "CPU set should fail",
+			values:    testStringToVals("", "a", "0-", ",1", "0,,1", "0 1"),
+			wantFail:  true,
+			validator: MatchCPUSet(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_IDMap(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchIDMap(),
+		},
+		{
+			desc:      "ID map is valid",
+			values:    testStringToVals("0:100000:65536", "1000:1000:1"),
+			validator: MatchIDMap(),
+		},
+		{
+			desc:      "ID map should fail",
+			values:    testStringToVals("", "0:100000", "0:100000:0", "a:b:c", "0:1:2:3", "-1:0:1"),
+			wantFail:  true,
+			validator: MatchIDMap(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_AbsolutePath(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchAbsolutePath(),
+		},
+		{
+			desc:      "Absolute path is valid",
+			values:    testStringToVals("/", "/data", "/dev/fuse"),
+			validator: MatchAbsolutePath(),
+		},
+		{
+			desc:      "Absolute path should fail",
+			values:    testStringToVals("", "data", "./data", "/data:/other"),
+			wantFail:  true,
+			validator: MatchAbsolutePath(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_DevicePermissions(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchDevicePermissions(),
+		},
+		{
+			desc:      "Device permissions is valid",
+			values:    testStringToVals("r", "rw", "rwm", "mr", "wmr"),
+			validator: MatchDevicePermissions(),
+		},
+		{
+			desc:      "Device permissions should fail",
+			values:    testStringToVals("", "rr", "rwx", "rwmr", "a"),
+			wantFail:  true,
+			validator: MatchDevicePermissions(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_Device(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchDevice(),
+		},
+		{
+			desc:      "Device is valid",
+			values:    testStringToVals("/dev/fuse", "/dev/ttyUSB0", "nvidia.com/gpu=0", "vendor.com/class=all", "example.com/net-device=eth:1"),
+			validator: MatchDevice(),
+		},
+		{
+			desc:      "Device should fail",
+			values:    testStringToVals("", "fuse", "dev/fuse", "nvidia.com/gpu", "gpu=0", "/dev/fuse:/dev/fuse"),
+			wantFail:  true,
+			validator: MatchDevice(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_Size(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchSize(),
+		},
+		{
+			desc:      "Size is valid",
+			values:    testStringToVals("4096", "512m", "10GB", "1.5g", "100kb", "2T"),
+			validator: MatchSize(),
+		},
+		{
+			desc:      "Size should fail",
+			values:    testStringToVals("", "m", "10 GB", "-1", "1x", "10%"),
+			wantFail:  true,
+			validator: MatchSize(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
+
+func TestStringValidator_MountOption(t *testing.T) {
+	tests := []testValidatorStringCase{
+		{
+			desc: "Null and Unknown is valid",
+			values: []types.String{
+				types.StringUnknown(),
+				types.StringNull(),
+			},
+			validator: MatchMountOption(),
+		},
+		{
+			desc:      "Mount option is valid",
+			values:    testStringToVals("noatime", "addr=10.0.0.1", "nfsvers=4.2", "rw"),
+			validator: MatchMountOption(),
+		},
+		{
+			desc:      "Mount option should fail",
+			values:    testStringToVals("", "rw,noatime", "addr= 10.0.0.1"),
+			wantFail:  true,
+			validator: MatchMountOption(),
+		},
+	}
+	testValidatorStringExecute(t, tests)
+}
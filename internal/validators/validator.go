@@ -27,3 +27,47 @@ func (v *genericStringValidator) ValidateString(ctx context.Context, req validat
 	}
 	v.validate(ctx, req, resp)
 }
+
+type (
+	genericObjectValidator struct {
+		description string
+		validate    func(context.Context, validator.ObjectRequest, *validator.ObjectResponse)
+	}
+)
+
+func (v *genericObjectValidator) Description(ctx context.Context) string {
+	return v.description
+}
+
+func (v *genericObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *genericObjectValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+	v.validate(ctx, req, resp)
+}
+
+type (
+	genericSetValidator struct {
+		description string
+		validate    func(context.Context, validator.SetRequest, *validator.SetResponse)
+	}
+)
+
+func (v *genericSetValidator) Description(ctx context.Context) string {
+	return v.description
+}
+
+func (v *genericSetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *genericSetValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+	v.validate(ctx, req, resp)
+}